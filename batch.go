@@ -0,0 +1,108 @@
+package flashbots
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// DialOption configures the client returned by [Dial] or [DialWithSigner].
+type DialOption func(*dialOptions)
+
+type dialOptions struct {
+	batchSplitting bool
+	protectConfig  *ProtectConfig
+}
+
+// WithBatchSplitting splits batch requests into single requests that are sent
+// concurrently and signed separately. The single responses are reassembled
+// into a batch response in the order of the batch request.
+//
+// Use WithBatchSplitting to send multiple calls with [w3.Client.Call] to an
+// endpoint that does not support batch requests, like the Flashbots relay.
+func WithBatchSplitting() DialOption {
+	return func(opts *dialOptions) { opts.batchSplitting = true }
+}
+
+// batchSplitRoundTripper splits JSON-RPC batch requests into single requests.
+type batchSplitRoundTripper struct {
+	next http.RoundTripper
+}
+
+func (b *batchSplitRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Body == nil {
+		return b.next.RoundTrip(r)
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body.Close()
+
+	var elems []json.RawMessage
+	if trimmed := bytes.TrimSpace(body); len(trimmed) <= 0 || trimmed[0] != '[' ||
+		json.Unmarshal(trimmed, &elems) != nil || len(elems) <= 0 {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		return b.next.RoundTrip(r)
+	}
+
+	// send single requests concurrently
+	var (
+		wg    sync.WaitGroup
+		resps = make([]*http.Response, len(elems))
+		errs  = make([]error, len(elems))
+	)
+	for i, elem := range elems {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req := r.Clone(r.Context())
+			req.Body = io.NopCloser(bytes.NewReader(elem))
+			req.ContentLength = int64(len(elem))
+			req.GetBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(elem)), nil
+			}
+			resps[i], errs[i] = b.next.RoundTrip(req)
+		}()
+	}
+	wg.Wait()
+
+	// return the first error or failed response as is
+	for i, resp := range resps {
+		if errs[i] == nil && resp.StatusCode/100 == 2 {
+			continue
+		}
+		for j, other := range resps {
+			if j != i && other != nil {
+				other.Body.Close()
+			}
+		}
+		return resp, errs[i]
+	}
+
+	// reassemble batch response
+	buf := bytes.NewBufferString("[")
+	for i, resp := range resps {
+		defer resp.Body.Close()
+
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(bytes.TrimSpace(respBody))
+	}
+	buf.WriteByte(']')
+
+	resp := resps[0]
+	resp.Body = io.NopCloser(buf)
+	resp.ContentLength = int64(buf.Len())
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
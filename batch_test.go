@@ -0,0 +1,132 @@
+package flashbots_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lmittmann/flashbots"
+	"github.com/lmittmann/flashbots/flashbotstest"
+)
+
+func TestWithBatchSplitting(t *testing.T) {
+	prv, _ := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+	addr := crypto.PubkeyToAddress(prv.PublicKey)
+
+	srv := flashbotstest.NewServer()
+	defer srv.Close()
+	srv.SetHighPriority(addr, true)
+
+	tx0, tx1 := newTx(prv, 0, 1), newTx(prv, 1, 1)
+
+	t.Run("without_batch_splitting", func(t *testing.T) {
+		client := flashbots.MustDial(srv.URL(), prv)
+		defer client.Close()
+
+		var (
+			bundleHash common.Hash
+			userStats  *flashbots.UserStatsV2Response
+		)
+		if err := client.Call(
+			flashbots.SendBundle(&flashbots.SendBundleRequest{
+				Transactions: types.Transactions{tx0},
+				BlockNumber:  big.NewInt(9_999_999),
+			}).Returns(&bundleHash),
+			flashbots.UserStatsV2(big.NewInt(9_999_999)).Returns(&userStats),
+		); err == nil {
+			t.Fatal("Batch request did not fail")
+		}
+	})
+
+	t.Run("with_batch_splitting", func(t *testing.T) {
+		client := flashbots.MustDial(srv.URL(), prv, flashbots.WithBatchSplitting())
+		defer client.Close()
+
+		var (
+			bundleHash0 common.Hash
+			userStats   *flashbots.UserStatsV2Response
+			bundleHash1 common.Hash
+		)
+		if err := client.Call(
+			flashbots.SendBundle(&flashbots.SendBundleRequest{
+				Transactions: types.Transactions{tx0},
+				BlockNumber:  big.NewInt(9_999_999),
+			}).Returns(&bundleHash0),
+			flashbots.UserStatsV2(big.NewInt(9_999_999)).Returns(&userStats),
+			flashbots.SendBundle(&flashbots.SendBundleRequest{
+				Transactions: types.Transactions{tx1},
+				BlockNumber:  big.NewInt(9_999_999),
+			}).Returns(&bundleHash1),
+		); err != nil {
+			t.Fatalf("Failed to call: %v", err)
+		}
+
+		if want := flashbots.BundleHash(types.Transactions{tx0}); want != bundleHash0 {
+			t.Fatalf("Bundle hash 0: want %s, got %s", want, bundleHash0)
+		}
+		if want := flashbots.BundleHash(types.Transactions{tx1}); want != bundleHash1 {
+			t.Fatalf("Bundle hash 1: want %s, got %s", want, bundleHash1)
+		}
+		if !userStats.IsHighPriority {
+			t.Fatal("Searcher not high priority")
+		}
+		if len(srv.Bundles()) != 2 {
+			t.Fatalf("Want 2 bundles, got %d", len(srv.Bundles()))
+		}
+	})
+
+	t.Run("single_call", func(t *testing.T) {
+		client := flashbots.MustDial(srv.URL(), prv, flashbots.WithBatchSplitting())
+		defer client.Close()
+
+		var userStats *flashbots.UserStatsV2Response
+		if err := client.Call(
+			flashbots.UserStatsV2(big.NewInt(9_999_999)).Returns(&userStats),
+		); err != nil {
+			t.Fatalf("Failed to call: %v", err)
+		}
+	})
+}
+
+func TestWithBatchSplittingHTTPError(t *testing.T) {
+	prv, _ := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+
+	// respond with status 429 to the second request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Method == "flashbots_getUserStatsV2" {
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(req.ID) + `,"result":true}`))
+	}))
+	defer srv.Close()
+
+	client := flashbots.MustDial(srv.URL, prv, flashbots.WithBatchSplitting())
+	defer client.Close()
+
+	var (
+		canceled  bool
+		userStats *flashbots.UserStatsV2Response
+	)
+	err := client.Call(
+		flashbots.CancelPrivateTx(common.Hash{}).Returns(&canceled),
+		flashbots.UserStatsV2(big.NewInt(9_999_999)).Returns(&userStats),
+	)
+	var httpErr rpc.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusTooManyRequests || string(httpErr.Body) != "too many requests\n" {
+		t.Fatalf("Err: want 429 rpc.HTTPError, got %v", err)
+	}
+}
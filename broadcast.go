@@ -0,0 +1,118 @@
+package flashbots
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// Endpoint is a named RPC endpoint of a relay or builder.
+type Endpoint struct {
+	Name    string        // Unique name of the endpoint.
+	URL     string        // RPC URL of the endpoint.
+	Timeout time.Duration // Timeout of a single call to the endpoint, no timeout if zero.
+}
+
+// BroadcastResult is the result of a broadcast to a single [Endpoint].
+type BroadcastResult struct {
+	Hash common.Hash // Bundle or tx hash returned by the endpoint.
+	Err  error       // Error returned by the endpoint.
+}
+
+// Broadcaster sends the same call to multiple endpoints concurrently.
+type Broadcaster struct {
+	endpoints []Endpoint
+	clients   []*w3.Client
+}
+
+// NewBroadcaster returns a new [Broadcaster] that is connected to the given
+// endpoints. Every request is signed with the private key prv using the
+// [AuthTransport]. An error is returned if the connection establishment to any
+// of the endpoints fails.
+func NewBroadcaster(endpoints []Endpoint, prv *ecdsa.PrivateKey) (*Broadcaster, error) {
+	b := &Broadcaster{
+		endpoints: endpoints,
+		clients:   make([]*w3.Client, 0, len(endpoints)),
+	}
+
+	names := make(map[string]struct{}, len(endpoints))
+	for _, endpoint := range endpoints {
+		if _, ok := names[endpoint.Name]; ok {
+			b.Close()
+			return nil, fmt.Errorf("flashbots: duplicate endpoint name %q", endpoint.Name)
+		}
+		names[endpoint.Name] = struct{}{}
+
+		client, err := Dial(endpoint.URL, prv)
+		if err != nil {
+			b.Close()
+			return nil, fmt.Errorf("flashbots: endpoint %q: %w", endpoint.Name, err)
+		}
+		b.clients = append(b.clients, client)
+	}
+	return b, nil
+}
+
+// SendBundle sends the bundle to all endpoints and returns the result of each
+// endpoint by name.
+func (b *Broadcaster) SendBundle(ctx context.Context, r *SendBundleRequest) map[string]BroadcastResult {
+	return b.broadcast(ctx, func() w3types.RPCCallerFactory[common.Hash] {
+		return SendBundle(r)
+	})
+}
+
+// SendPrivateTx sends the private transaction to all endpoints and returns the
+// result of each endpoint by name.
+func (b *Broadcaster) SendPrivateTx(ctx context.Context, r *SendPrivateTxRequest) map[string]BroadcastResult {
+	return b.broadcast(ctx, func() w3types.RPCCallerFactory[common.Hash] {
+		return SendPrivateTx(r)
+	})
+}
+
+func (b *Broadcaster) broadcast(ctx context.Context, newCall func() w3types.RPCCallerFactory[common.Hash]) map[string]BroadcastResult {
+	var (
+		mux     sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]BroadcastResult, len(b.clients))
+	)
+	for i, client := range b.clients {
+		wg.Add(1)
+		go func(endpoint Endpoint, client *w3.Client) {
+			defer wg.Done()
+
+			callCtx := ctx
+			if endpoint.Timeout > 0 {
+				var cancel context.CancelFunc
+				callCtx, cancel = context.WithTimeout(ctx, endpoint.Timeout)
+				defer cancel()
+			}
+
+			var res BroadcastResult
+			res.Err = client.CallCtx(callCtx, newCall().Returns(&res.Hash))
+
+			mux.Lock()
+			defer mux.Unlock()
+			results[endpoint.Name] = res
+		}(b.endpoints[i], client)
+	}
+	wg.Wait()
+	return results
+}
+
+// Close closes the connections to all endpoints.
+func (b *Broadcaster) Close() error {
+	var errs []error
+	for _, client := range b.clients {
+		if err := client.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,103 @@
+package flashbots_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/flashbots"
+	"github.com/lmittmann/w3"
+)
+
+func TestBroadcasterSendBundle(t *testing.T) {
+	prv, _ := crypto.GenerateKey()
+
+	newServer := func(result string, delay time.Duration) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Flashbots-Signature") == "" {
+				t.Errorf("Missing X-Flashbots-Signature header")
+			}
+
+			var req struct {
+				ID     json.RawMessage `json:"id"`
+				Method string          `json:"method"`
+			}
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &req); err != nil {
+				t.Errorf("Failed to decode request: %v", err)
+			}
+			if req.Method != "eth_sendBundle" {
+				t.Errorf("Method: want %q, got %q", "eth_sendBundle", req.Method)
+			}
+
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,%s}`, req.ID, result)
+		}))
+	}
+
+	srvA := newServer(`"result":{"bundleHash":"0x2228f5d8954ce31dc1601a8ba264dbd401bf1428388ce88238932815c5d6f23f"}`, 0)
+	defer srvA.Close()
+	srvB := newServer(`"result":{"bundleHash":"0x73b1e258c7a42fd0230b2fd05529c5d4b6fcb66c227783f8bece8aeacdd1db2e"}`, 0)
+	defer srvB.Close()
+	srvC := newServer(`"error":{"code":-32000,"message":"bundle already known"}`, 0)
+	defer srvC.Close()
+	srvD := newServer(`"result":{"bundleHash":"0x2228f5d8954ce31dc1601a8ba264dbd401bf1428388ce88238932815c5d6f23f"}`, time.Second)
+	defer srvD.Close()
+
+	b, err := flashbots.NewBroadcaster([]flashbots.Endpoint{
+		{Name: "a", URL: srvA.URL},
+		{Name: "b", URL: srvB.URL},
+		{Name: "c", URL: srvC.URL},
+		{Name: "d", URL: srvD.URL, Timeout: 10 * time.Millisecond},
+	}, prv)
+	if err != nil {
+		t.Fatalf("Failed to create broadcaster: %v", err)
+	}
+	defer b.Close()
+
+	gotResults := b.SendBundle(context.Background(), &flashbots.SendBundleRequest{
+		RawTransactions: [][]byte{w3.B("0x00"), w3.B("0x01")},
+		BlockNumber:     big.NewInt(9_999_999),
+	})
+	wantResults := map[string]flashbots.BroadcastResult{
+		"a": {Hash: w3.H("0x2228f5d8954ce31dc1601a8ba264dbd401bf1428388ce88238932815c5d6f23f")},
+		"b": {Hash: w3.H("0x73b1e258c7a42fd0230b2fd05529c5d4b6fcb66c227783f8bece8aeacdd1db2e")},
+		"c": {Err: errors.New("w3: call failed: bundle already known")},
+	}
+	if err := gotResults["d"].Err; !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Err: want %v, got %v", context.DeadlineExceeded, err)
+	}
+	delete(gotResults, "d")
+
+	if diff := cmp.Diff(wantResults, gotResults, cmp.Comparer(func(x, y error) bool {
+		return x == nil && y == nil || x != nil && y != nil && x.Error() == y.Error()
+	})); diff != "" {
+		t.Fatalf("(-want, +got)\n%s", diff)
+	}
+}
+
+func TestNewBroadcasterDuplicateName(t *testing.T) {
+	prv, _ := crypto.GenerateKey()
+
+	_, err := flashbots.NewBroadcaster([]flashbots.Endpoint{
+		{Name: "a", URL: "http://localhost:1"},
+		{Name: "a", URL: "http://localhost:2"},
+	}, prv)
+	if want := `flashbots: duplicate endpoint name "a"`; err == nil || err.Error() != want {
+		t.Fatalf("Err: want %q, got %v", want, err)
+	}
+}
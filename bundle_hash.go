@@ -0,0 +1,64 @@
+package flashbots
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// BundleHash returns the hash of a bundle with the given txs, as returned by
+// [SendBundle] and [CallBundle]. The bundle hash is the Keccak256 hash of the
+// concatenated tx hashes.
+func BundleHash(txs types.Transactions) common.Hash {
+	txHashes := make([]common.Hash, len(txs))
+	for i, tx := range txs {
+		txHashes[i] = tx.Hash()
+	}
+	return hashConcat(txHashes)
+}
+
+// MevBundleHash returns the hash of a MEV-Share bundle with the given body, as
+// returned by [MevSendBundle]. The bundle hash is the Keccak256 hash of the
+// concatenated hashes of the body elements, where the hash of a nested bundle
+// is its bundle hash. The bundle hash of a body with a single element is the
+// hash of that element.
+func MevBundleHash(body []MevBundleBody) (common.Hash, error) {
+	hashes := make([]common.Hash, len(body))
+	for i, el := range body {
+		switch {
+		case el.Hash != nil:
+			hashes[i] = *el.Hash
+		case el.Tx != nil:
+			hashes[i] = el.Tx.Hash()
+		case el.RawTx != nil:
+			tx := new(types.Transaction)
+			if err := tx.UnmarshalBinary(el.RawTx); err != nil {
+				return common.Hash{}, err
+			}
+			hashes[i] = tx.Hash()
+		case el.Bundle != nil:
+			hash, err := MevBundleHash(el.Bundle.Body)
+			if err != nil {
+				return common.Hash{}, err
+			}
+			hashes[i] = hash
+		default:
+			return common.Hash{}, errors.New("flashbots: empty bundle body element")
+		}
+	}
+	if len(hashes) == 1 {
+		return hashes[0], nil
+	}
+	return hashConcat(hashes), nil
+}
+
+// hashConcat returns the Keccak256 hash of the concatenated hashes.
+func hashConcat(hashes []common.Hash) common.Hash {
+	data := make([]byte, 0, len(hashes)*common.HashLength)
+	for _, hash := range hashes {
+		data = append(data, hash[:]...)
+	}
+	return crypto.Keccak256Hash(data)
+}
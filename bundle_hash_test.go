@@ -0,0 +1,111 @@
+package flashbots_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/flashbots"
+	"github.com/lmittmann/w3"
+)
+
+// signed txs with nonce 0 and 1 of the key 0x00…01 (see newTx) and their hashes
+var (
+	bundleRawTx0  = w3.B("0x02f8670180018502540be40082520894c0fe0000000000000000000000000000000000008080c001a0aafcb1c6b0de18b5d6c098bb2b8390e4910d44e64d927b684ff3d26c8f66f255a053f32b1a221fa0ae4bf2f07d58ca9b8213ae1babf0d9c676d2131306d39029a7")
+	bundleRawTx1  = w3.B("0x02f8670101018502540be40082520894c0fe0000000000000000000000000000000000008080c001a014adbb207e98e127b677f33a08ded3551c6044710846b74a478efcdc986979a2a06375fbcfce4087b86317ef8ea7a6e2d2d1d0ba8cefddfe699cd85549a05981dc")
+	bundleTxHash0 = w3.H("0xcba9fe7c1fce198ffc1ba39f2a61338eb0cc225f1cd5545de02dac9873b5a4d1")
+	bundleTxHash1 = w3.H("0x5e26d07d8cd503c607d6c9af04e52919fda79ca48827f1d1a9f5064b06709279")
+)
+
+// decodeTx decodes the given raw tx.
+func decodeTx(t *testing.T, rawTx []byte) *types.Transaction {
+	t.Helper()
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(rawTx); err != nil {
+		t.Fatalf("Failed to decode tx: %v", err)
+	}
+	return tx
+}
+
+func TestBundleHash(t *testing.T) {
+	tx0, tx1 := decodeTx(t, bundleRawTx0), decodeTx(t, bundleRawTx1)
+	if tx0.Hash() != bundleTxHash0 || tx1.Hash() != bundleTxHash1 {
+		t.Fatalf("Unexpected tx hashes %s, %s", tx0.Hash(), tx1.Hash())
+	}
+
+	got := flashbots.BundleHash(types.Transactions{tx0, tx1})
+	want := w3.H("0x87ab2197ebdea71cf68c792bd2df0a62b4aec6b258f6c34714fee5d13fd949aa")
+	if want != got {
+		t.Fatalf("want %s, got %s", want, got)
+	}
+}
+
+func TestMevBundleHash(t *testing.T) {
+	tx0, tx1 := decodeTx(t, bundleRawTx0), decodeTx(t, bundleRawTx1)
+
+	// tx hashes and bundle hash from testdata/call_bundle.golden
+	var (
+		txHash0 = w3.H("0x669b4704a7d993a946cdd6e2f95233f308ce0c4649d2e04944e8299efcaa098a")
+		txHash1 = w3.H("0xa839ee83465657cac01adc1d50d96c1b586ed498120a84a64749c0034b4f19fa")
+	)
+
+	tests := []struct {
+		Name string
+		Body []flashbots.MevBundleBody
+		Want common.Hash
+	}{
+		{
+			Name: "hashes",
+			Body: []flashbots.MevBundleBody{{Hash: &txHash0}, {Hash: &txHash1}},
+			Want: w3.H("0x73b1e258c7a42fd0230b2fd05529c5d4b6fcb66c227783f8bece8aeacdd1db2e"),
+		},
+		{
+			Name: "txs",
+			Body: []flashbots.MevBundleBody{{Tx: tx0}, {RawTx: bundleRawTx1}},
+			Want: w3.H("0x87ab2197ebdea71cf68c792bd2df0a62b4aec6b258f6c34714fee5d13fd949aa"),
+		},
+		{
+			Name: "nested",
+			Body: []flashbots.MevBundleBody{
+				{Hash: &txHash0},
+				{Bundle: &flashbots.MevSendBundleRequest{
+					Body: []flashbots.MevBundleBody{{Tx: tx0}, {Tx: tx1}},
+				}},
+			},
+			Want: w3.H("0xa02242c971f0ba39cfd795e2278ae36b15e738456428de78c457e8a59db8f092"),
+		},
+		{
+			Name: "single_hash",
+			Body: []flashbots.MevBundleBody{{Hash: &txHash0}},
+			Want: txHash0,
+		},
+		{
+			Name: "single_tx",
+			Body: []flashbots.MevBundleBody{{RawTx: bundleRawTx1}},
+			Want: bundleTxHash1,
+		},
+		{
+			Name: "nested_single",
+			Body: []flashbots.MevBundleBody{
+				{Hash: &txHash0},
+				{Bundle: &flashbots.MevSendBundleRequest{
+					Body: []flashbots.MevBundleBody{{Tx: tx1}},
+				}},
+			},
+			Want: w3.H("0xdb741fa975a269a1d3ff873ff84d70c067e482b5ada630b648eec13224686d9d"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got, err := flashbots.MevBundleHash(test.Body)
+			if err != nil {
+				t.Fatalf("Failed to hash bundle: %v", err)
+			}
+			if test.Want != got {
+				t.Fatalf("want %s, got %s", test.Want, got)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -19,13 +20,32 @@ type CallBundleRequest struct {
 	BlockNumber      *big.Int           // Block number for which the bundle is valid.
 	StateBlockNumber *big.Int           // Block number of state to use for simulation, "latest" if nil.
 	Timestamp        uint64             // Timestamp of block used for simulation (Optional).
+	Coinbase         *common.Address    // Coinbase of block used for simulation (Optional).
+	BaseFee          *big.Int           // Base fee of block used for simulation (Optional).
+	GasLimit         uint64             // Gas limit of block used for simulation (Optional).
+	Difficulty       *big.Int           // Difficulty of block used for simulation (Optional).
+	Timeout          time.Duration      // Timeout of the simulation, encoded in milliseconds (Optional).
+	StateOverrides   w3types.State      // State overrides applied before the simulation (Optional).
 }
 
 type callBundleRequest struct {
-	RawTransactions  []hexutil.Bytes `json:"txs"`
-	BlockNumber      *hexutil.Big    `json:"blockNumber"`
-	StateBlockNumber string          `json:"stateBlockNumber"`
-	Timestamp        uint64          `json:"timestamp,omitempty"`
+	RawTransactions  []hexutil.Bytes                          `json:"txs"`
+	BlockNumber      *hexutil.Big                             `json:"blockNumber"`
+	StateBlockNumber string                                   `json:"stateBlockNumber"`
+	Timestamp        uint64                                   `json:"timestamp,omitempty"`
+	Coinbase         *common.Address                          `json:"coinbase,omitempty"`
+	BaseFee          *big.Int                                 `json:"baseFee,omitempty"`
+	GasLimit         uint64                                   `json:"gasLimit,omitempty"`
+	Difficulty       *big.Int                                 `json:"difficulty,omitempty"`
+	Timeout          int64                                    `json:"timeout,omitempty"`
+	StateOverrides   map[common.Address]*stateOverrideAccount `json:"stateOverrides,omitempty"`
+}
+
+type stateOverrideAccount struct {
+	Nonce     hexutil.Uint64  `json:"nonce,omitempty"`
+	Balance   *hexutil.Big    `json:"balance,omitempty"`
+	Code      hexutil.Bytes   `json:"code,omitempty"`
+	StateDiff w3types.Storage `json:"stateDiff,omitempty"`
 }
 
 // MarshalJSON implements the [json.Marshaler].
@@ -50,9 +70,71 @@ func (c CallBundleRequest) MarshalJSON() ([]byte, error) {
 	enc.BlockNumber = (*hexutil.Big)(c.BlockNumber)
 	enc.StateBlockNumber = toBlockNumberArg(c.StateBlockNumber)
 	enc.Timestamp = c.Timestamp
+	enc.Coinbase = c.Coinbase
+	enc.BaseFee = c.BaseFee
+	enc.GasLimit = c.GasLimit
+	enc.Difficulty = c.Difficulty
+	enc.Timeout = c.Timeout.Milliseconds()
+	if len(c.StateOverrides) > 0 {
+		enc.StateOverrides = make(map[common.Address]*stateOverrideAccount, len(c.StateOverrides))
+		for addr, acc := range c.StateOverrides {
+			if acc == nil {
+				continue
+			}
+			enc.StateOverrides[addr] = &stateOverrideAccount{
+				Nonce:     hexutil.Uint64(acc.Nonce),
+				Balance:   (*hexutil.Big)(acc.Balance),
+				Code:      acc.Code,
+				StateDiff: acc.Storage,
+			}
+		}
+	}
 	return json.Marshal(&enc)
 }
 
+// UnmarshalJSON implements the [json.Unmarshaler]. Transactions are decoded
+// into RawTransactions.
+func (c *CallBundleRequest) UnmarshalJSON(input []byte) error {
+	var dec callBundleRequest
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+
+	c.RawTransactions = make([][]byte, len(dec.RawTransactions))
+	for i, rawTx := range dec.RawTransactions {
+		c.RawTransactions[i] = rawTx
+	}
+	c.BlockNumber = (*big.Int)(dec.BlockNumber)
+	if dec.StateBlockNumber != "" && dec.StateBlockNumber != "latest" {
+		stateBlockNumber, err := hexutil.DecodeBig(dec.StateBlockNumber)
+		if err != nil {
+			return err
+		}
+		c.StateBlockNumber = stateBlockNumber
+	}
+	c.Timestamp = dec.Timestamp
+	c.Coinbase = dec.Coinbase
+	c.BaseFee = dec.BaseFee
+	c.GasLimit = dec.GasLimit
+	c.Difficulty = dec.Difficulty
+	c.Timeout = time.Duration(dec.Timeout) * time.Millisecond
+	if len(dec.StateOverrides) > 0 {
+		c.StateOverrides = make(w3types.State, len(dec.StateOverrides))
+		for addr, acc := range dec.StateOverrides {
+			if acc == nil {
+				continue
+			}
+			c.StateOverrides[addr] = &w3types.Account{
+				Nonce:   uint64(acc.Nonce),
+				Balance: (*big.Int)(acc.Balance),
+				Code:    acc.Code,
+				Storage: acc.StateDiff,
+			}
+		}
+	}
+	return nil
+}
+
 type CallBundleResponse struct {
 	BundleGasPrice    *big.Int
 	BundleHash        common.Hash
@@ -204,11 +286,35 @@ func (f *callBundleFactory) CreateRequest() (rpc.BatchElem, error) {
 // HandleResponse implements the [w3types.ResponseHandler].
 func (f *callBundleFactory) HandleResponse(elem rpc.BatchElem) error {
 	if err := elem.Error; err != nil {
-		return err
+		return wrapRPCError(err)
 	}
 	return nil
 }
 
+func (f *callBundleFactory) validate() error { return f.param.Validate() }
+
+// txs returns the bundles transactions.
+func (c *CallBundleRequest) txs() (types.Transactions, error) {
+	return decodeTxs(c.Transactions, c.RawTransactions)
+}
+
+// decodeTxs returns txs if it is not empty, or otherwise the decoded rawTxs.
+func decodeTxs(txs types.Transactions, rawTxs [][]byte) (types.Transactions, error) {
+	if len(txs) > 0 {
+		return txs, nil
+	}
+
+	txs = make(types.Transactions, len(rawTxs))
+	for i, rawTx := range rawTxs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(rawTx); err != nil {
+			return nil, err
+		}
+		txs[i] = tx
+	}
+	return txs, nil
+}
+
 func toBlockNumberArg(blockNumber *big.Int) string {
 	if blockNumber == nil || blockNumber.Sign() < 0 {
 		return "latest"
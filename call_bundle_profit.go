@@ -0,0 +1,96 @@
+package flashbots
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ProfitParams are the parameters of the profitability analysis of a bundle.
+type ProfitParams struct {
+	BaseFee      *big.Int // Base fee of the target block.
+	Revenue      *big.Int // Gross revenue of the bundle for the searcher, e.g. the arbitrage profit (Optional).
+	InputCosts   *big.Int // Costs of the bundle besides gas and coinbase payments, e.g. flash loan fees (Optional).
+	CompetingBid *big.Int // Coinbase payment of a competing bundle (Optional).
+}
+
+// ProfitReport is the profitability analysis of a simulated bundle.
+type ProfitReport struct {
+	BaseFee           *big.Int // Base fee of the target block.
+	PriorityFeePerGas *big.Int // Effective priority fee per gas of the bundle, i.e. the coinbase payment per gas.
+	GasPrice          *big.Int // Effective gas price of the bundle, i.e. the sum of BaseFee and PriorityFeePerGas.
+	BurnedFees        *big.Int // Base fees burned by the bundle.
+	CoinbasePayment   *big.Int // Payment of the bundle to the coinbase, i.e. the priority fees and direct transfers.
+	TotalCosts        *big.Int // Sum of BurnedFees, CoinbasePayment and InputCosts.
+	NetProfit         *big.Int // Revenue minus TotalCosts.
+	BeatsCompetingBid bool     // CoinbasePayment is greater than the competing bid.
+	Txs               []TxProfitReport
+}
+
+// TxProfitReport is the profitability analysis of a tx of a simulated bundle.
+type TxProfitReport struct {
+	TxHash            common.Hash
+	CoinbasePayment   *big.Int // Payment of the tx to the coinbase.
+	PriorityFeePerGas *big.Int // Effective priority fee per gas of the tx, i.e. the coinbase payment per gas.
+	Share             float64  // Share of the tx in the coinbase payment of the bundle in the range [0, 1].
+}
+
+// Profit returns the profitability analysis of the simulated bundle for the
+// given parameters. Nil parameters are treated as empty parameters. The
+// returned report does not share any values with the response or parameters.
+func (c *CallBundleResponse) Profit(p *ProfitParams) *ProfitReport {
+	if p == nil {
+		p = new(ProfitParams)
+	}
+
+	var (
+		baseFee         = bigOrZero(p.BaseFee)
+		coinbasePayment = bigOrZero(c.CoinbaseDiff)
+		totalGasUsed    = new(big.Int).SetUint64(c.TotalGasUsed)
+	)
+
+	report := &ProfitReport{
+		BaseFee:           baseFee,
+		PriorityFeePerGas: perGas(coinbasePayment, c.TotalGasUsed),
+		BurnedFees:        new(big.Int).Mul(baseFee, totalGasUsed),
+		CoinbasePayment:   coinbasePayment,
+		Txs:               make([]TxProfitReport, len(c.Results)),
+	}
+	report.GasPrice = new(big.Int).Add(baseFee, report.PriorityFeePerGas)
+	report.TotalCosts = new(big.Int).Add(report.BurnedFees, coinbasePayment)
+	report.TotalCosts.Add(report.TotalCosts, bigOrZero(p.InputCosts))
+	report.NetProfit = new(big.Int).Sub(bigOrZero(p.Revenue), report.TotalCosts)
+	report.BeatsCompetingBid = coinbasePayment.Cmp(bigOrZero(p.CompetingBid)) > 0
+
+	for i, res := range c.Results {
+		txCoinbasePayment := bigOrZero(res.CoinbaseDiff)
+
+		var share float64
+		if coinbasePayment.Sign() != 0 {
+			share, _ = new(big.Rat).SetFrac(txCoinbasePayment, coinbasePayment).Float64()
+		}
+		report.Txs[i] = TxProfitReport{
+			TxHash:            res.TxHash,
+			CoinbasePayment:   txCoinbasePayment,
+			PriorityFeePerGas: perGas(txCoinbasePayment, res.GasUsed),
+			Share:             share,
+		}
+	}
+	return report
+}
+
+// perGas returns the value per gas, or 0 if gas is 0.
+func perGas(value *big.Int, gas uint64) *big.Int {
+	if gas == 0 {
+		return new(big.Int)
+	}
+	return new(big.Int).Div(value, new(big.Int).SetUint64(gas))
+}
+
+// bigOrZero returns a copy of x, or 0 if x is nil.
+func bigOrZero(x *big.Int) *big.Int {
+	if x == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(x)
+}
@@ -0,0 +1,160 @@
+package flashbots_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/flashbots"
+	"github.com/lmittmann/w3"
+)
+
+func TestCallBundleResponseProfit(t *testing.T) {
+	var (
+		txHash0 = w3.H("0x669b4704a7d993a946cdd6e2f95233f308ce0c4649d2e04944e8299efcaa098a")
+		txHash1 = w3.H("0xa839ee83465657cac01adc1d50d96c1b586ed498120a84a64749c0034b4f19fa")
+	)
+
+	tests := []struct {
+		Name       string
+		Response   *flashbots.CallBundleResponse
+		Params     *flashbots.ProfitParams
+		WantReport *flashbots.ProfitReport
+	}{
+		{
+			Name: "profit",
+			Response: &flashbots.CallBundleResponse{
+				CoinbaseDiff: w3.I("0.03 ether"),
+				TotalGasUsed: 84_000,
+				Results: []flashbots.CallBundleResult{
+					{TxHash: txHash0, CoinbaseDiff: w3.I("0.01 ether"), GasUsed: 21_000},
+					{TxHash: txHash1, CoinbaseDiff: w3.I("0.02 ether"), GasUsed: 63_000},
+				},
+			},
+			Params: &flashbots.ProfitParams{
+				BaseFee:      w3.I("10 gwei"),
+				Revenue:      w3.I("0.05 ether"),
+				InputCosts:   w3.I("0.001 ether"),
+				CompetingBid: w3.I("0.025 ether"),
+			},
+			WantReport: &flashbots.ProfitReport{
+				BaseFee:           w3.I("10 gwei"),
+				PriorityFeePerGas: w3.I("357142857142"),
+				GasPrice:          w3.I("367142857142"),
+				BurnedFees:        w3.I("0.00084 ether"),
+				CoinbasePayment:   w3.I("0.03 ether"),
+				TotalCosts:        w3.I("0.03184 ether"),
+				NetProfit:         w3.I("0.01816 ether"),
+				BeatsCompetingBid: true,
+				Txs: []flashbots.TxProfitReport{
+					{TxHash: txHash0, CoinbasePayment: w3.I("0.01 ether"), PriorityFeePerGas: w3.I("476190476190"), Share: 1. / 3},
+					{TxHash: txHash1, CoinbasePayment: w3.I("0.02 ether"), PriorityFeePerGas: w3.I("317460317460"), Share: 2. / 3},
+				},
+			},
+		},
+		{
+			Name: "loss",
+			Response: &flashbots.CallBundleResponse{
+				CoinbaseDiff: w3.I("0.02 ether"),
+				TotalGasUsed: 100_000,
+				Results: []flashbots.CallBundleResult{
+					{TxHash: txHash0, CoinbaseDiff: w3.I("0.02 ether"), GasUsed: 100_000},
+				},
+			},
+			Params: &flashbots.ProfitParams{
+				BaseFee:      w3.I("20 gwei"),
+				Revenue:      w3.I("0.02 ether"),
+				CompetingBid: w3.I("0.02 ether"),
+			},
+			WantReport: &flashbots.ProfitReport{
+				BaseFee:           w3.I("20 gwei"),
+				PriorityFeePerGas: w3.I("200 gwei"),
+				GasPrice:          w3.I("220 gwei"),
+				BurnedFees:        w3.I("0.002 ether"),
+				CoinbasePayment:   w3.I("0.02 ether"),
+				TotalCosts:        w3.I("0.022 ether"),
+				NetProfit:         new(big.Int).Neg(w3.I("0.002 ether")),
+				BeatsCompetingBid: false,
+				Txs: []flashbots.TxProfitReport{
+					{TxHash: txHash0, CoinbasePayment: w3.I("0.02 ether"), PriorityFeePerGas: w3.I("200 gwei"), Share: 1},
+				},
+			},
+		},
+		{
+			Name:     "empty",
+			Response: &flashbots.CallBundleResponse{},
+			Params:   &flashbots.ProfitParams{},
+			WantReport: &flashbots.ProfitReport{
+				BaseFee:           new(big.Int),
+				PriorityFeePerGas: new(big.Int),
+				GasPrice:          new(big.Int),
+				BurnedFees:        new(big.Int),
+				CoinbasePayment:   new(big.Int),
+				TotalCosts:        new(big.Int),
+				NetProfit:         new(big.Int),
+				Txs:               []flashbots.TxProfitReport{},
+			},
+		},
+		{
+			Name: "nil_params",
+			Response: &flashbots.CallBundleResponse{
+				CoinbaseDiff: w3.I("0.01 ether"),
+				TotalGasUsed: 21_000,
+				Results: []flashbots.CallBundleResult{
+					{TxHash: txHash0, CoinbaseDiff: w3.I("0.01 ether"), GasUsed: 21_000},
+				},
+			},
+			Params: nil,
+			WantReport: &flashbots.ProfitReport{
+				BaseFee:           new(big.Int),
+				PriorityFeePerGas: w3.I("476190476190"),
+				GasPrice:          w3.I("476190476190"),
+				BurnedFees:        new(big.Int),
+				CoinbasePayment:   w3.I("0.01 ether"),
+				TotalCosts:        w3.I("0.01 ether"),
+				NetProfit:         new(big.Int).Neg(w3.I("0.01 ether")),
+				BeatsCompetingBid: true,
+				Txs: []flashbots.TxProfitReport{
+					{TxHash: txHash0, CoinbasePayment: w3.I("0.01 ether"), PriorityFeePerGas: w3.I("476190476190"), Share: 1},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			gotReport := test.Response.Profit(test.Params)
+			if diff := cmp.Diff(test.WantReport, gotReport,
+				cmp.Comparer(func(x, y *big.Int) bool { return x.Cmp(y) == 0 }),
+			); diff != "" {
+				t.Fatalf("(-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCallBundleResponseProfitCopy(t *testing.T) {
+	var (
+		resp = &flashbots.CallBundleResponse{
+			CoinbaseDiff: w3.I("0.01 ether"),
+			TotalGasUsed: 21_000,
+			Results:      []flashbots.CallBundleResult{{CoinbaseDiff: w3.I("0.01 ether"), GasUsed: 21_000}},
+		}
+		params = &flashbots.ProfitParams{BaseFee: w3.I("10 gwei")}
+	)
+
+	report := resp.Profit(params)
+	report.BaseFee.SetUint64(1)
+	report.CoinbasePayment.SetUint64(1)
+	report.Txs[0].CoinbasePayment.SetUint64(1)
+
+	if want := w3.I("10 gwei"); params.BaseFee.Cmp(want) != 0 {
+		t.Fatalf("BaseFee: want %v, got %v", want, params.BaseFee)
+	}
+	if want := w3.I("0.01 ether"); resp.CoinbaseDiff.Cmp(want) != 0 {
+		t.Fatalf("CoinbaseDiff: want %v, got %v", want, resp.CoinbaseDiff)
+	}
+	if want := w3.I("0.01 ether"); resp.Results[0].CoinbaseDiff.Cmp(want) != 0 {
+		t.Fatalf("Results[0].CoinbaseDiff: want %v, got %v", want, resp.Results[0].CoinbaseDiff)
+	}
+}
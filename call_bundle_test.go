@@ -1,11 +1,16 @@
 package flashbots_test
 
 import (
+	"encoding/json"
+	"math/big"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/lmittmann/flashbots"
 	"github.com/lmittmann/w3"
 	"github.com/lmittmann/w3/rpctest"
+	"github.com/lmittmann/w3/w3types"
 )
 
 func TestCallBundle(t *testing.T) {
@@ -52,5 +57,108 @@ func TestCallBundle(t *testing.T) {
 				},
 			},
 		},
+		{
+			Golden: "call_bundle_overrides",
+			Call: flashbots.CallBundle(&flashbots.CallBundleRequest{
+				RawTransactions:  [][]byte{w3.B("0x00"), w3.B("0x01")},
+				BlockNumber:      w3.I("0xb63dcd"),
+				StateBlockNumber: w3.I("0xb63dcc"),
+				Timestamp:        1615920932,
+				Coinbase:         w3.APtr("0x000000000000000000000000000000000000c0Fe"),
+				BaseFee:          w3.I("1 gwei"),
+				GasLimit:         30_000_000,
+				Difficulty:       new(big.Int),
+				Timeout:          2500 * time.Millisecond,
+				StateOverrides: w3types.State{
+					w3.A("0x02A727155aeF8609c9f7F2179b2a1f560B39F5A0"): {
+						Nonce:   1,
+						Balance: w3.I("1 ether"),
+						Code:    w3.B("0x60006000fd"),
+						Storage: w3types.Storage{common.BigToHash(big.NewInt(1)): common.BigToHash(big.NewInt(42))},
+					},
+				},
+			}),
+			WantRet: &flashbots.CallBundleResponse{
+				BundleGasPrice:    w3.I("476190476193"),
+				BundleHash:        w3.H("0x73b1e258c7a42fd0230b2fd05529c5d4b6fcb66c227783f8bece8aeacdd1db2e"),
+				CoinbaseDiff:      w3.I("20000000000126000"),
+				EthSentToCoinbase: w3.I("20000000000000000"),
+				GasFees:           w3.I("126000"),
+				StateBlockNumber:  w3.I("5221585"),
+				TotalGasUsed:      42000,
+				Results: []flashbots.CallBundleResult{
+					{
+						CoinbaseDiff:      w3.I("10000000000063000"),
+						EthSentToCoinbase: w3.I("10000000000000000"),
+						FromAddress:       w3.A("0x02A727155aeF8609c9f7F2179b2a1f560B39F5A0"),
+						GasFees:           w3.I("63000"),
+						GasPrice:          w3.I("476190476193"),
+						GasUsed:           21000,
+						ToAddress:         w3.APtr("0x73625f59CAdc5009Cb458B751b3E7b6b48C06f2C"),
+						TxHash:            w3.H("0x669b4704a7d993a946cdd6e2f95233f308ce0c4649d2e04944e8299efcaa098a"),
+						Value:             w3.B("0x"),
+					},
+					{
+						CoinbaseDiff:      w3.I("10000000000063000"),
+						EthSentToCoinbase: w3.I("10000000000000000"),
+						FromAddress:       w3.A("0x02A727155aeF8609c9f7F2179b2a1f560B39F5A0"),
+						GasFees:           w3.I("63000"),
+						GasPrice:          w3.I("476190476193"),
+						GasUsed:           21000,
+						ToAddress:         w3.APtr("0x73625f59CAdc5009Cb458B751b3E7b6b48C06f2C"),
+						TxHash:            w3.H("0xa839ee83465657cac01adc1d50d96c1b586ed498120a84a64749c0034b4f19fa"),
+						Value:             w3.B("0x"),
+					},
+				},
+			},
+		},
+		{
+			Golden: "call_bundle_overrides_balance",
+			Call: flashbots.CallBundle(&flashbots.CallBundleRequest{
+				RawTransactions: [][]byte{w3.B("0x00")},
+				BlockNumber:     w3.I("0xb63dcd"),
+				StateOverrides: w3types.State{
+					w3.A("0x02A727155aeF8609c9f7F2179b2a1f560B39F5A0"): {Balance: w3.I("1 ether")},
+					w3.A("0x000000000000000000000000000000000000c0Fe"): nil,
+				},
+			}),
+			WantRet: &flashbots.CallBundleResponse{
+				BundleGasPrice:    w3.I("476190476193"),
+				BundleHash:        w3.H("0x73b1e258c7a42fd0230b2fd05529c5d4b6fcb66c227783f8bece8aeacdd1db2e"),
+				CoinbaseDiff:      w3.I("10000000000063000"),
+				EthSentToCoinbase: w3.I("10000000000000000"),
+				GasFees:           w3.I("63000"),
+				StateBlockNumber:  w3.I("5221585"),
+				TotalGasUsed:      21000,
+				Results: []flashbots.CallBundleResult{
+					{
+						CoinbaseDiff:      w3.I("10000000000063000"),
+						EthSentToCoinbase: w3.I("10000000000000000"),
+						FromAddress:       w3.A("0x02A727155aeF8609c9f7F2179b2a1f560B39F5A0"),
+						GasFees:           w3.I("63000"),
+						GasPrice:          w3.I("476190476193"),
+						GasUsed:           21000,
+						ToAddress:         w3.APtr("0x73625f59CAdc5009Cb458B751b3E7b6b48C06f2C"),
+						TxHash:            w3.H("0x669b4704a7d993a946cdd6e2f95233f308ce0c4649d2e04944e8299efcaa098a"),
+						Value:             w3.B("0x"),
+					},
+				},
+			},
+		},
 	})
 }
+
+func TestCallBundleRequestUnmarshalJSON(t *testing.T) {
+	var req flashbots.CallBundleRequest
+	if err := json.Unmarshal([]byte(`{"txs":["0x00"],"blockNumber":"0xb63dcd","stateOverrides":{"0x02a727155aef8609c9f7f2179b2a1f560b39f5a0":{"nonce":"0x2","balance":"0x1"},"0x000000000000000000000000000000000000c0fe":null}}`), &req); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	if len(req.StateOverrides) != 1 {
+		t.Fatalf("StateOverrides: want 1 account, got %d", len(req.StateOverrides))
+	}
+	acc := req.StateOverrides[w3.A("0x02A727155aeF8609c9f7F2179b2a1f560B39F5A0")]
+	if acc == nil || acc.Nonce != 2 || acc.Balance.Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("StateOverrides: unexpected account %+v", acc)
+	}
+}
@@ -0,0 +1,193 @@
+package flashbots
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/eth"
+	"github.com/lmittmann/w3/w3vm"
+)
+
+// BundleSimulator simulates bundles locally in a [w3vm.VM]. It is an
+// alternative to [CallBundle] that does not require a relay or mev-geth node.
+type BundleSimulator struct {
+	client *w3.Client
+	opts   []w3vm.Option
+}
+
+// NewBundleSimulator returns a new [BundleSimulator] that forks state from the
+// given client. If client is nil, bundles are simulated against the in-memory
+// state that is set with the given VM options (e.g. [w3vm.WithState]).
+func NewBundleSimulator(client *w3.Client, opts ...w3vm.Option) *BundleSimulator {
+	return &BundleSimulator{client: client, opts: opts}
+}
+
+// CallBundle simulates the bundle on top of the state at
+// [CallBundleRequest.StateBlockNumber] and returns the same response as
+// [CallBundle]. All block and state overrides of the request, except the
+// Timeout, are applied.
+//
+// Transactions that revert do not fail the simulation, but are reported in
+// the corresponding [CallBundleResult]. Transactions that are invalid (e.g. due
+// to an invalid nonce or insufficient funds) fail the simulation.
+func (s *BundleSimulator) CallBundle(r *CallBundleRequest) (*CallBundleResponse, error) {
+	txs, err := r.txs()
+	if err != nil {
+		return nil, err
+	}
+
+	header, stateBlockNumber, err := s.header(r)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []w3vm.Option{w3vm.WithHeader(header)}
+	if s.client != nil {
+		opts = append(opts, w3vm.WithFetcher(w3vm.NewRPCFetcher(s.client, stateBlockNumber)))
+	}
+	vm, err := w3vm.New(append(opts, s.opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	for addr, acc := range r.StateOverrides {
+		if acc == nil {
+			continue
+		}
+		if acc.Nonce > 0 {
+			vm.SetNonce(addr, acc.Nonce)
+		}
+		if acc.Balance != nil {
+			vm.SetBalance(addr, acc.Balance)
+		}
+		if len(acc.Code) > 0 {
+			vm.SetCode(addr, acc.Code)
+		}
+		for slot, val := range acc.Storage {
+			vm.SetStorageAt(addr, slot, val)
+		}
+	}
+
+	resp := &CallBundleResponse{
+		CoinbaseDiff:      new(big.Int),
+		EthSentToCoinbase: new(big.Int),
+		GasFees:           new(big.Int),
+		StateBlockNumber:  stateBlockNumber,
+		Results:           make([]CallBundleResult, len(txs)),
+	}
+
+	for i, tx := range txs {
+		txHash := tx.Hash()
+
+		from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			return nil, fmt.Errorf("flashbots: tx %s: %w", txHash, err)
+		}
+
+		coinbaseBalanceBefore, err := vm.Balance(header.Coinbase)
+		if err != nil {
+			return nil, err
+		}
+		receipt, err := vm.ApplyTx(tx)
+		if receipt == nil {
+			return nil, fmt.Errorf("flashbots: tx %s: %w", txHash, err)
+		}
+		coinbaseBalanceAfter, err := vm.Balance(header.Coinbase)
+		if err != nil {
+			return nil, err
+		}
+
+		gasPrice, err := tx.EffectiveGasTip(header.BaseFee)
+		if err != nil {
+			return nil, fmt.Errorf("flashbots: tx %s: %w", txHash, err)
+		}
+		gasFees := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(receipt.GasUsed))
+		coinbaseDiff := new(big.Int).Sub(coinbaseBalanceAfter, coinbaseBalanceBefore)
+
+		res := &resp.Results[i]
+		res.CoinbaseDiff = coinbaseDiff
+		res.EthSentToCoinbase = new(big.Int).Sub(coinbaseDiff, gasFees)
+		res.FromAddress = from
+		res.GasFees = gasFees
+		res.GasPrice = gasPrice
+		res.GasUsed = receipt.GasUsed
+		res.ToAddress = tx.To()
+		res.TxHash = txHash
+		if receipt.Err != nil {
+			res.Error = w3vm.ErrRevert
+			res.Revert = strings.TrimPrefix(strings.TrimPrefix(receipt.Err.Error(), w3vm.ErrRevert.Error()), ": ")
+		} else {
+			res.Value = receipt.Output
+		}
+
+		resp.CoinbaseDiff.Add(resp.CoinbaseDiff, res.CoinbaseDiff)
+		resp.EthSentToCoinbase.Add(resp.EthSentToCoinbase, res.EthSentToCoinbase)
+		resp.GasFees.Add(resp.GasFees, res.GasFees)
+		resp.TotalGasUsed += res.GasUsed
+	}
+
+	resp.BundleHash = BundleHash(txs)
+	resp.BundleGasPrice = new(big.Int)
+	if resp.TotalGasUsed > 0 {
+		resp.BundleGasPrice.Div(resp.CoinbaseDiff, new(big.Int).SetUint64(resp.TotalGasUsed))
+	}
+	return resp, nil
+}
+
+// header returns the header of the simulated block and the block number of
+// the state the bundle is simulated on.
+func (s *BundleSimulator) header(r *CallBundleRequest) (*types.Header, *big.Int, error) {
+	header := new(types.Header)
+
+	var stateBlockNumber *big.Int
+	if s.client != nil {
+		var parent *types.Header
+		if err := s.client.Call(
+			eth.HeaderByNumber(r.StateBlockNumber).Returns(&parent),
+		); err != nil {
+			return nil, nil, err
+		}
+		stateBlockNumber = parent.Number
+
+		header.ParentHash = parent.Hash()
+		header.Number = new(big.Int).Add(parent.Number, w3.Big1)
+		header.Time = parent.Time + 12
+		header.Coinbase = parent.Coinbase
+		header.GasLimit = parent.GasLimit
+		header.Difficulty = new(big.Int)
+		header.MixDigest = parent.MixDigest
+		if parent.BaseFee != nil {
+			header.BaseFee = eip1559.CalcBaseFee(params.MainnetChainConfig, parent)
+		}
+	} else {
+		stateBlockNumber = r.StateBlockNumber
+		header.Number = new(big.Int)
+		header.GasLimit = params.MaxTxGas
+		header.Difficulty = new(big.Int)
+		header.BaseFee = new(big.Int)
+	}
+
+	if r.BlockNumber != nil {
+		header.Number = r.BlockNumber
+	}
+	if r.Timestamp > 0 {
+		header.Time = r.Timestamp
+	}
+	if r.Coinbase != nil {
+		header.Coinbase = *r.Coinbase
+	}
+	if r.BaseFee != nil {
+		header.BaseFee = r.BaseFee
+	}
+	if r.GasLimit > 0 {
+		header.GasLimit = r.GasLimit
+	}
+	if r.Difficulty != nil {
+		header.Difficulty = r.Difficulty
+	}
+	return header, stateBlockNumber, nil
+}
@@ -0,0 +1,156 @@
+package flashbots_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/flashbots"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/w3types"
+	"github.com/lmittmann/w3/w3vm"
+)
+
+func TestBundleSimulatorCallBundle(t *testing.T) {
+	var (
+		prv, _   = crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+		addr     = crypto.PubkeyToAddress(prv.PublicKey)
+		coinbase = common.Address{}
+		revertTo = w3.A("0x000000000000000000000000000000000000dEaD")
+		signer   = types.LatestSigner(params.MergedTestChainConfig)
+	)
+
+	tx0 := types.MustSignNewTx(prv, signer, &types.DynamicFeeTx{
+		Nonce:     0,
+		GasTipCap: w3.I("1 gwei"),
+		GasFeeCap: w3.I("1 gwei"),
+		Gas:       21_000,
+		To:        &coinbase,
+		Value:     w3.I("0.01 ether"),
+	})
+	tx1 := types.MustSignNewTx(prv, signer, &types.DynamicFeeTx{
+		Nonce:     1,
+		GasTipCap: w3.I("2 gwei"),
+		GasFeeCap: w3.I("2 gwei"),
+		Gas:       50_000,
+		To:        &revertTo,
+	})
+
+	sim := flashbots.NewBundleSimulator(nil, w3vm.WithState(w3types.State{
+		addr:     {Balance: w3.I("1 ether")},
+		revertTo: {Code: w3.B("0x60006000fd")}, // PUSH1 0 PUSH1 0 REVERT
+	}))
+
+	gotResp, err := sim.CallBundle(&flashbots.CallBundleRequest{
+		Transactions: types.Transactions{tx0, tx1},
+		BlockNumber:  big.NewInt(1),
+		Timestamp:    1615920932,
+	})
+	if err != nil {
+		t.Fatalf("Failed to simulate bundle: %v", err)
+	}
+
+	wantResp := &flashbots.CallBundleResponse{
+		BundleGasPrice:    w3.I("239561300766"),
+		BundleHash:        crypto.Keccak256Hash(tx0.Hash().Bytes(), tx1.Hash().Bytes()),
+		CoinbaseDiff:      w3.I("10063012000000000"),
+		EthSentToCoinbase: w3.I("10000000000000000"),
+		GasFees:           w3.I("63012000000000"),
+		TotalGasUsed:      42_006,
+		Results: []flashbots.CallBundleResult{
+			{
+				CoinbaseDiff:      w3.I("10021000000000000"),
+				EthSentToCoinbase: w3.I("10000000000000000"),
+				FromAddress:       addr,
+				GasFees:           w3.I("21000000000000"),
+				GasPrice:          w3.I("1 gwei"),
+				GasUsed:           21_000,
+				ToAddress:         &coinbase,
+				TxHash:            tx0.Hash(),
+			},
+			{
+				CoinbaseDiff:      w3.I("42012000000000"),
+				EthSentToCoinbase: new(big.Int),
+				FromAddress:       addr,
+				GasFees:           w3.I("42012000000000"),
+				GasPrice:          w3.I("2 gwei"),
+				GasUsed:           21_006,
+				ToAddress:         &revertTo,
+				TxHash:            tx1.Hash(),
+				Error:             w3vm.ErrRevert,
+			},
+		},
+	}
+	if diff := cmp.Diff(wantResp, gotResp,
+		cmp.Comparer(func(x, y *big.Int) bool { return x == nil && y == nil || x != nil && y != nil && x.Cmp(y) == 0 }),
+		cmp.Comparer(func(x, y error) bool { return errors.Is(x, y) }),
+	); diff != "" {
+		t.Fatalf("(-want, +got)\n%s", diff)
+	}
+}
+
+func TestBundleSimulatorCallBundleInvalidTx(t *testing.T) {
+	prv, _ := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+
+	tx := types.MustSignNewTx(prv, types.LatestSigner(params.MergedTestChainConfig), &types.DynamicFeeTx{
+		GasTipCap: w3.I("1 gwei"),
+		GasFeeCap: w3.I("1 gwei"),
+		Gas:       21_000,
+		To:        &common.Address{},
+		Value:     w3.I("1 ether"),
+	})
+
+	sim := flashbots.NewBundleSimulator(nil)
+	if _, err := sim.CallBundle(&flashbots.CallBundleRequest{
+		Transactions: types.Transactions{tx},
+		BlockNumber:  big.NewInt(1),
+	}); err == nil {
+		t.Fatal("Want error, got nil")
+	}
+}
+
+func TestBundleSimulatorCallBundleOverrides(t *testing.T) {
+	var (
+		prv, _   = crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000002")
+		addr     = crypto.PubkeyToAddress(prv.PublicKey)
+		coinbase = w3.A("0x000000000000000000000000000000000000c0Fe")
+		to       = w3.A("0x000000000000000000000000000000000000dEaD")
+		signer   = types.LatestSigner(params.MergedTestChainConfig)
+	)
+
+	tx := types.MustSignNewTx(prv, signer, &types.DynamicFeeTx{
+		Nonce:     5,
+		GasTipCap: w3.I("1 gwei"),
+		GasFeeCap: w3.I("3 gwei"),
+		Gas:       21_000,
+		To:        &to,
+	})
+
+	// the sender only exists in the state overrides of the request
+	sim := flashbots.NewBundleSimulator(nil)
+	gotResp, err := sim.CallBundle(&flashbots.CallBundleRequest{
+		Transactions: types.Transactions{tx},
+		BlockNumber:  big.NewInt(1),
+		Coinbase:     &coinbase,
+		BaseFee:      w3.I("2 gwei"),
+		GasLimit:     21_000,
+		StateOverrides: w3types.State{
+			addr: {Nonce: 5, Balance: w3.I("1 ether")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to simulate bundle: %v", err)
+	}
+
+	if want := w3.I("21000 gwei"); gotResp.CoinbaseDiff.Cmp(want) != 0 {
+		t.Fatalf("CoinbaseDiff: want %v, got %v", want, gotResp.CoinbaseDiff)
+	}
+	if want := w3.I("1 gwei"); gotResp.Results[0].GasPrice.Cmp(want) != 0 {
+		t.Fatalf("GasPrice: want %v, got %v", want, gotResp.Results[0].GasPrice)
+	}
+}
@@ -0,0 +1,148 @@
+package flashbots
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Errors returned by relays and builders. Errors returned by the factories of
+// this package wrap one of these errors (or [ErrInvalidSignature]) if the
+// error response of the relay or builder matches it. The [*HTTPError] of a
+// non-2xx response with status 429, 401 or 403 also wraps one of these errors.
+//
+// Note that the w3.CallErrors returned by w3.Client.Call must be unwrapped
+// first to check the error of an individual call.
+var (
+	ErrRateLimited        = errors.New("flashbots: rate limited")
+	ErrBundleTooOld       = errors.New("flashbots: bundle too old")
+	ErrUnknownBundle      = errors.New("flashbots: unknown bundle")
+	ErrBundleAlreadyKnown = errors.New("flashbots: bundle already known")
+)
+
+// RPCError is a JSON-RPC error response of a relay or builder.
+type RPCError struct {
+	Code    int    // JSON-RPC error code.
+	Message string // JSON-RPC error message.
+	Data    any    // JSON-RPC error data (Optional).
+
+	err error // known error the response matches
+}
+
+func (e *RPCError) Error() string { return e.Message }
+
+// ErrorCode implements the [rpc.Error].
+func (e *RPCError) ErrorCode() int { return e.Code }
+
+// ErrorData implements the [rpc.DataError].
+func (e *RPCError) ErrorData() any { return e.Data }
+
+// Unwrap returns the known error the response matches, or nil.
+func (e *RPCError) Unwrap() error { return e.err }
+
+// HTTPError is a non-2xx HTTP response of a relay or builder. It is returned
+// by clients created with [Dial] or [DialWithSigner], wrapped in a
+// [*url.Error].
+//
+// HTTPError wraps the [rpc.HTTPError] of the response, so errors.As with an
+// [rpc.HTTPError] target matches it, and the known error the response status
+// matches, i.e. [ErrRateLimited] for status 429 and [ErrInvalidSignature] for
+// status 401 and 403.
+type HTTPError struct {
+	rpc.HTTPError
+
+	err error // known error the response matches
+}
+
+// Unwrap returns the [rpc.HTTPError] of the response and the known error the
+// response matches, if any.
+func (e *HTTPError) Unwrap() []error {
+	if e.err == nil {
+		return []error{e.HTTPError}
+	}
+	return []error{e.HTTPError, e.err}
+}
+
+// maxErrorBodySize is the max number of bytes of a non-2xx response body that
+// are read into an [HTTPError].
+const maxErrorBodySize = 4 << 10 // 4 KiB
+
+// httpErrorRoundTripper turns non-2xx responses into an [*HTTPError].
+type httpErrorRoundTripper struct {
+	next http.RoundTripper
+}
+
+func (rt *httpErrorRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := rt.next.RoundTrip(r)
+	if err != nil || resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	e := &HTTPError{HTTPError: rpc.HTTPError{StatusCode: resp.StatusCode, Status: resp.Status, Body: body}}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		e.err = ErrRateLimited
+	case http.StatusUnauthorized, http.StatusForbidden:
+		e.err = ErrInvalidSignature
+	}
+	return nil, e
+}
+
+// authErrorMessages are the lowercase error messages of the relay for requests
+// with a missing or invalid 'X-Flashbots-Signature' header. Errors of the
+// [AuthHandler] are matched by the prefix [ErrInvalidSignature].
+var authErrorMessages = map[string]bool{
+	"invalid flashbots signature": true,
+	"missing flashbots signature": true,
+}
+
+// rpcErrorMessages maps substrings of lowercase error messages to known errors.
+var rpcErrorMessages = []struct {
+	Substr string
+	Err    error
+}{
+	{"rate limit", ErrRateLimited},
+	{"too many requests", ErrRateLimited},
+	{"too old", ErrBundleTooOld},
+	{"in the past", ErrBundleTooOld},
+	{"already known", ErrBundleAlreadyKnown},
+	{"unknown bundle", ErrUnknownBundle},
+	{"bundle not found", ErrUnknownBundle},
+}
+
+// wrapRPCError wraps the given JSON-RPC error in an [*RPCError] that matches
+// the corresponding known error. Other errors are returned as is.
+func wrapRPCError(err error) error {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return err
+	}
+
+	e := &RPCError{Code: rpcErr.ErrorCode(), Message: rpcErr.Error()}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		e.Data = dataErr.ErrorData()
+	}
+
+	if e.Code == -32005 { // limit exceeded (EIP-1474)
+		e.err = ErrRateLimited
+		return e
+	}
+	msg := strings.ToLower(e.Message)
+	if authErrorMessages[msg] || strings.HasPrefix(msg, ErrInvalidSignature.Error()) {
+		e.err = ErrInvalidSignature
+		return e
+	}
+	for _, m := range rpcErrorMessages {
+		if strings.Contains(msg, m.Substr) {
+			e.err = m.Err
+			break
+		}
+	}
+	return e
+}
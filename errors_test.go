@@ -0,0 +1,192 @@
+package flashbots_test
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/google/uuid"
+	"github.com/lmittmann/flashbots"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/rpctest"
+	"github.com/lmittmann/w3/w3types"
+)
+
+func TestRPCError(t *testing.T) {
+	sendBundle := func() w3types.RPCCaller {
+		return flashbots.SendBundle(&flashbots.SendBundleRequest{
+			RawTransactions: [][]byte{w3.B("0x00"), w3.B("0x01")},
+			BlockNumber:     big.NewInt(9_999_999),
+			ReplacementUuid: uuid.MustParse("2c9cf5d0-f13c-4b7a-b51d-f462fdb27b51"),
+		}).Returns(new(common.Hash))
+	}
+
+	tests := []struct {
+		Golden   string
+		Call     w3types.RPCCaller
+		WantErr  error // known error, nil if the error is unknown
+		WantCode int
+		WantData any
+	}{
+		{
+			Golden:   "send_bundle_rate_limited",
+			Call:     sendBundle(),
+			WantErr:  flashbots.ErrRateLimited,
+			WantCode: -32005,
+		},
+		{
+			Golden:   "send_bundle_invalid_signature",
+			Call:     sendBundle(),
+			WantErr:  flashbots.ErrInvalidSignature,
+			WantCode: -32600,
+		},
+		{
+			Golden:   "send_bundle_invalid_tx_signature",
+			Call:     sendBundle(),
+			WantCode: -32000,
+		},
+		{
+			Golden:   "send_bundle_too_old",
+			Call:     sendBundle(),
+			WantErr:  flashbots.ErrBundleTooOld,
+			WantCode: -32000,
+		},
+		{
+			Golden:   "send_bundle_already_known",
+			Call:     sendBundle(),
+			WantErr:  flashbots.ErrBundleAlreadyKnown,
+			WantCode: -32000,
+		},
+		{
+			Golden:   "send_bundle_internal_error",
+			Call:     sendBundle(),
+			WantCode: -32603,
+			WantData: "simulation failed",
+		},
+		{
+			Golden:   "cancel_bundle_unknown",
+			Call:     flashbots.CancelBundle(uuid.MustParse("2c9cf5d0-f13c-4b7a-b51d-f462fdb27b51")).Returns(new(bool)),
+			WantErr:  flashbots.ErrUnknownBundle,
+			WantCode: -32000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Golden, func(t *testing.T) {
+			srv := rpctest.NewFileServer(t, "testdata/"+test.Golden+".golden")
+			defer srv.Close()
+
+			client := w3.MustDial(srv.URL())
+			defer client.Close()
+
+			var callErrs w3.CallErrors
+			if err := client.Call(test.Call); !errors.As(err, &callErrs) {
+				t.Fatalf("Err: want w3.CallErrors, got %v", err)
+			}
+			err := callErrs[0]
+
+			var rpcErr *flashbots.RPCError
+			if !errors.As(err, &rpcErr) {
+				t.Fatalf("Err: want *flashbots.RPCError, got %T", err)
+			}
+			if test.WantCode != rpcErr.Code {
+				t.Fatalf("Code: want %d, got %d", test.WantCode, rpcErr.Code)
+			}
+			if test.WantData != rpcErr.Data {
+				t.Fatalf("Data: want %v, got %v", test.WantData, rpcErr.Data)
+			}
+			if gotErr := errors.Unwrap(err); test.WantErr != gotErr {
+				t.Fatalf("Err: want %v, got %v", test.WantErr, gotErr)
+			}
+			if test.WantErr != nil && !errors.Is(err, test.WantErr) {
+				t.Fatalf("Err: want errors.Is(%v)", test.WantErr)
+			}
+		})
+	}
+}
+
+func TestHTTPError(t *testing.T) {
+	prv, _ := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+	otherPrv, _ := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000002")
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":true}`))
+	})
+
+	tests := []struct {
+		Name           string
+		Handler        http.Handler
+		Signer         flashbots.Signer
+		WantErr        error
+		WantStatusCode int
+	}{
+		{
+			Name: "rate_limited",
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "too many requests", http.StatusTooManyRequests)
+			}),
+			Signer:         flashbots.NewPrivateKeySigner(prv),
+			WantErr:        flashbots.ErrRateLimited,
+			WantStatusCode: http.StatusTooManyRequests,
+		},
+		{
+			Name:    "invalid_signature",
+			Handler: flashbots.AuthHandler(ok),
+			// sign with a different key than the address in the header
+			Signer: flashbots.NewRemoteSigner(crypto.PubkeyToAddress(prv.PublicKey), func(digest []byte) ([]byte, error) {
+				return crypto.Sign(digest, otherPrv)
+			}),
+			WantErr:        flashbots.ErrInvalidSignature,
+			WantStatusCode: http.StatusForbidden,
+		},
+		{
+			Name: "internal_server_error",
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+			}),
+			Signer:         flashbots.NewPrivateKeySigner(prv),
+			WantStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			srv := httptest.NewServer(test.Handler)
+			defer srv.Close()
+
+			client, err := flashbots.DialWithSigner(srv.URL, test.Signer)
+			if err != nil {
+				t.Fatalf("Failed to dial: %v", err)
+			}
+			defer client.Close()
+
+			err = client.Call(flashbots.CancelPrivateTx(common.Hash{}).Returns(new(bool)))
+
+			var httpErr *flashbots.HTTPError
+			if !errors.As(err, &httpErr) {
+				t.Fatalf("Err: want *flashbots.HTTPError, got %v", err)
+			}
+			if test.WantStatusCode != httpErr.StatusCode {
+				t.Fatalf("Status code: want %d, got %d", test.WantStatusCode, httpErr.StatusCode)
+			}
+
+			// the rpc.HTTPError of go-ethereum is still reachable
+			var rpcHTTPErr rpc.HTTPError
+			if !errors.As(err, &rpcHTTPErr) || test.WantStatusCode != rpcHTTPErr.StatusCode {
+				t.Fatalf("Err: want rpc.HTTPError with status code %d, got %v", test.WantStatusCode, err)
+			}
+
+			for _, knownErr := range []error{flashbots.ErrRateLimited, flashbots.ErrInvalidSignature} {
+				if want, got := test.WantErr == knownErr, errors.Is(err, knownErr); want != got {
+					t.Fatalf("errors.Is(%v): want %t, got %t", knownErr, want, got)
+				}
+			}
+		})
+	}
+}
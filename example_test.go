@@ -1,6 +1,7 @@
 package flashbots_test
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
@@ -33,3 +34,45 @@ func Example() {
 	}
 	fmt.Printf("Sent bundle successfully: %s\n", bundleHash)
 }
+
+func ExampleSubscribeMevShare() {
+	// Private key for request signing
+	var prv *ecdsa.PrivateKey
+
+	// Connect to Flashbots relay
+	client := flashbots.MustDial("https://relay.flashbots.net", prv)
+	defer client.Close()
+
+	// Subscribe to the MEV-Share event stream
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := flashbots.SubscribeMevShare(ctx, flashbots.MevShareStreamURL)
+	if err != nil {
+		fmt.Printf("Failed to subscribe to MEV-Share event stream: %v\n", err)
+		return
+	}
+
+	for event := range events {
+		backrunTx := new(types.Transaction) // signed backrun transaction...
+
+		// Backrun the pending transaction or bundle of the event
+		var bundleHash common.Hash
+		if err := client.Call(
+			flashbots.MevSendBundle(&flashbots.MevSendBundleRequest{
+				Inclusion: flashbots.MevBundleInclusion{
+					BlockNumber:    big.NewInt(999_999_999),
+					MaxBlockNumber: big.NewInt(1_000_000_024),
+				},
+				Body: []flashbots.MevBundleBody{
+					{Hash: &event.Hash},
+					{Tx: backrunTx},
+				},
+			}).Returns(&bundleHash),
+		); err != nil {
+			fmt.Printf("Failed to send bundle to Flashbots relay: %v\n", err)
+			continue
+		}
+		fmt.Printf("Sent backrun bundle successfully: %s\n", bundleHash)
+	}
+}
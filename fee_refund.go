@@ -0,0 +1,313 @@
+package flashbots
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// FeeRefundTotalsByRecipient requests the total pending and received gas fee
+// refunds of the given recipient.
+func FeeRefundTotalsByRecipient(recipient common.Address) w3types.RPCCallerFactory[*FeeRefundTotalsResponse] {
+	return &feeRefundTotalsFactory{recipient: recipient}
+}
+
+// FeeRefundsByHash requests the gas fee refunds of the transaction or bundle
+// with the given hash.
+func FeeRefundsByHash(hash common.Hash) w3types.RPCCallerFactory[*FeeRefundsResponse] {
+	return &feeRefundsFactory{
+		method: "flashbots_getFeeRefundsByHash",
+		args:   []any{hash},
+	}
+}
+
+// FeeRefundsByRecipient requests a page of the gas fee refunds of the given
+// recipient. The first page is requested with an empty cursor, subsequent
+// pages with the [FeeRefundsResponse.Cursor] of the previous page.
+func FeeRefundsByRecipient(recipient common.Address, cursor string) w3types.RPCCallerFactory[*FeeRefundsResponse] {
+	return &feeRefundsFactory{
+		method: "flashbots_getFeeRefundsByRecipient",
+		args:   []any{&feeRefundsByRecipientRequest{Recipient: recipient, Cursor: cursor}},
+	}
+}
+
+// FeeRefundsByBundle requests a page of the gas fee refunds of the bundle with
+// the given hash. See [FeeRefundsByRecipient] for the cursor.
+func FeeRefundsByBundle(bundleHash common.Hash, cursor string) w3types.RPCCallerFactory[*FeeRefundsResponse] {
+	return &feeRefundsFactory{
+		method: "flashbots_getFeeRefundsByBundle",
+		args:   []any{&feeRefundsByBundleRequest{BundleHash: bundleHash, Cursor: cursor}},
+	}
+}
+
+// FeeRefundsByBlock requests a page of the gas fee refunds of the given block.
+// See [FeeRefundsByRecipient] for the cursor.
+func FeeRefundsByBlock(blockNumber *big.Int, cursor string) w3types.RPCCallerFactory[*FeeRefundsResponse] {
+	return &feeRefundsFactory{
+		method: "flashbots_getFeeRefundsByBlock",
+		args:   []any{&feeRefundsByBlockRequest{BlockNumber: (*hexutil.Big)(blockNumber), Cursor: cursor}},
+	}
+}
+
+// SetFeeRefundRecipient delegates the gas fee refunds of the delegate to the
+// given recipient. By default refunds are paid to the address that signed the
+// transaction or bundle.
+//
+// The request must be signed by the key of the delegate, i.e. sent via a
+// client created with [Dial] or [AuthTransport] for the delegating key.
+func SetFeeRefundRecipient(delegate, recipient common.Address) w3types.RPCCallerFactory[*SetFeeRefundRecipientResponse] {
+	return &setFeeRefundRecipientFactory{delegate: delegate, recipient: recipient}
+}
+
+// FeeRefunds returns an iterator over the gas fee refunds of all pages of a
+// paginated query. The query of a page is created by calling page with the
+// cursor of the page, starting with an empty cursor for the first page.
+//
+// Iteration stops after the last page, an empty or null page, or the first
+// error.
+//
+// Example:
+//
+//	for refund, err := range flashbots.FeeRefunds(ctx, client, func(cursor string) w3types.RPCCallerFactory[*flashbots.FeeRefundsResponse] {
+//		return flashbots.FeeRefundsByRecipient(recipient, cursor)
+//	}) {
+//		// ...
+//	}
+func FeeRefunds(ctx context.Context, client *w3.Client, page func(cursor string) w3types.RPCCallerFactory[*FeeRefundsResponse]) iter.Seq2[*FeeRefund, error] {
+	return func(yield func(*FeeRefund, error) bool) {
+		var cursor string
+		for {
+			var resp *FeeRefundsResponse
+			if err := client.CallCtx(ctx, page(cursor).Returns(&resp)); err != nil {
+				yield(nil, err)
+				return
+			}
+			if resp == nil {
+				return
+			}
+			for _, refund := range resp.Refunds {
+				if !yield(refund, nil) {
+					return
+				}
+			}
+
+			if len(resp.Refunds) == 0 || resp.Cursor == "" || resp.Cursor == cursor {
+				return
+			}
+			cursor = resp.Cursor
+		}
+	}
+}
+
+type feeRefundsByRecipientRequest struct {
+	Recipient common.Address `json:"recipient"`
+	Cursor    string         `json:"cursor,omitempty"`
+}
+
+type feeRefundsByBundleRequest struct {
+	BundleHash common.Hash `json:"bundle_hash"`
+	Cursor     string      `json:"cursor,omitempty"`
+}
+
+type feeRefundsByBlockRequest struct {
+	BlockNumber *hexutil.Big `json:"block_number"`
+	Cursor      string       `json:"cursor,omitempty"`
+}
+
+// FeeRefundStatus is the status of a gas fee refund.
+type FeeRefundStatus string
+
+const (
+	FeeRefundPending  FeeRefundStatus = "pending"  // Refund is not yet paid.
+	FeeRefundReceived FeeRefundStatus = "received" // Refund was paid to the recipient.
+)
+
+type FeeRefundTotalsResponse struct {
+	Pending  *big.Int // Total amount of refunds that are not yet paid.
+	Received *big.Int // Total amount of refunds that were paid.
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (f *FeeRefundTotalsResponse) UnmarshalJSON(input []byte) error {
+	type feeRefundTotalsResponse struct {
+		Pending  *hexutil.Big `json:"pending"`
+		Received *hexutil.Big `json:"received"`
+	}
+
+	var dec feeRefundTotalsResponse
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+
+	f.Pending = (*big.Int)(dec.Pending)
+	f.Received = (*big.Int)(dec.Received)
+	return nil
+}
+
+type FeeRefundsResponse struct {
+	Refunds []*FeeRefund
+	Cursor  string // Cursor of the next page, empty if this is the last page.
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (f *FeeRefundsResponse) UnmarshalJSON(input []byte) error {
+	type feeRefundsResponse struct {
+		Refunds []*FeeRefund `json:"refunds"`
+		Cursor  string       `json:"cursor"`
+	}
+
+	var dec feeRefundsResponse
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+
+	f.Refunds = dec.Refunds
+	f.Cursor = dec.Cursor
+	return nil
+}
+
+type FeeRefund struct {
+	Hash        common.Hash     // Hash of the refunded transaction or bundle.
+	Amount      *big.Int        // Amount of the refund.
+	BlockNumber uint64          // Number of the block in which the transaction or bundle was included.
+	Status      FeeRefundStatus // Status of the refund.
+	Recipient   common.Address  // Recipient of the refund.
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (f *FeeRefund) UnmarshalJSON(input []byte) error {
+	type feeRefund struct {
+		Hash        common.Hash     `json:"hash"`
+		Amount      *hexutil.Big    `json:"amount"`
+		BlockNumber hexutil.Uint64  `json:"blockNumber"`
+		Status      FeeRefundStatus `json:"status"`
+		Recipient   common.Address  `json:"recipient"`
+	}
+
+	var dec feeRefund
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+
+	f.Hash = dec.Hash
+	f.Amount = (*big.Int)(dec.Amount)
+	f.BlockNumber = uint64(dec.BlockNumber)
+	f.Status = dec.Status
+	f.Recipient = dec.Recipient
+	return nil
+}
+
+type SetFeeRefundRecipientResponse struct {
+	From common.Address // Address whose refunds are delegated.
+	To   common.Address // New recipient of the refunds.
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (s *SetFeeRefundRecipientResponse) UnmarshalJSON(input []byte) error {
+	type setFeeRefundRecipientResponse struct {
+		From common.Address `json:"from"`
+		To   common.Address `json:"to"`
+	}
+
+	var dec setFeeRefundRecipientResponse
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+
+	s.From = dec.From
+	s.To = dec.To
+	return nil
+}
+
+type feeRefundTotalsFactory struct {
+	// args
+	recipient common.Address
+
+	// returns
+	returns **FeeRefundTotalsResponse
+}
+
+func (f *feeRefundTotalsFactory) Returns(totals **FeeRefundTotalsResponse) w3types.RPCCaller {
+	f.returns = totals
+	return f
+}
+
+func (f *feeRefundTotalsFactory) CreateRequest() (rpc.BatchElem, error) {
+	return rpc.BatchElem{
+		Method: "flashbots_getFeeRefundTotalsByRecipient",
+		Args:   []any{f.recipient},
+		Result: f.returns,
+	}, nil
+}
+
+func (f *feeRefundTotalsFactory) HandleResponse(elem rpc.BatchElem) error {
+	if err := elem.Error; err != nil {
+		return wrapRPCError(err)
+	}
+	return nil
+}
+
+type feeRefundsFactory struct {
+	// args
+	method string
+	args   []any
+
+	// returns
+	returns **FeeRefundsResponse
+}
+
+func (f *feeRefundsFactory) Returns(refunds **FeeRefundsResponse) w3types.RPCCaller {
+	f.returns = refunds
+	return f
+}
+
+func (f *feeRefundsFactory) CreateRequest() (rpc.BatchElem, error) {
+	return rpc.BatchElem{
+		Method: f.method,
+		Args:   f.args,
+		Result: f.returns,
+	}, nil
+}
+
+func (f *feeRefundsFactory) HandleResponse(elem rpc.BatchElem) error {
+	if err := elem.Error; err != nil {
+		return wrapRPCError(err)
+	}
+	return nil
+}
+
+type setFeeRefundRecipientFactory struct {
+	// args
+	delegate  common.Address
+	recipient common.Address
+
+	// returns
+	returns **SetFeeRefundRecipientResponse
+}
+
+func (f *setFeeRefundRecipientFactory) Returns(resp **SetFeeRefundRecipientResponse) w3types.RPCCaller {
+	f.returns = resp
+	return f
+}
+
+func (f *setFeeRefundRecipientFactory) CreateRequest() (rpc.BatchElem, error) {
+	return rpc.BatchElem{
+		Method: "flashbots_setFeeRefundRecipient",
+		Args:   []any{f.delegate, f.recipient},
+		Result: f.returns,
+	}, nil
+}
+
+func (f *setFeeRefundRecipientFactory) HandleResponse(elem rpc.BatchElem) error {
+	if err := elem.Error; err != nil {
+		return wrapRPCError(err)
+	}
+	return nil
+}
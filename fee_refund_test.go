@@ -0,0 +1,200 @@
+package flashbots_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/flashbots"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/rpctest"
+	"github.com/lmittmann/w3/w3types"
+)
+
+var feeRefund = &flashbots.FeeRefund{
+	Hash:        w3.H("0x669b4704a7d993a946cdd6e2f95233f308ce0c4649d2e04944e8299efcaa098a"),
+	Amount:      w3.I("0.01234 ether"),
+	BlockNumber: 18_000_000,
+	Status:      flashbots.FeeRefundReceived,
+	Recipient:   w3.A("0x000000000000000000000000000000000000c0Fe"),
+}
+
+func TestFeeRefundTotalsByRecipient(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*flashbots.FeeRefundTotalsResponse]{
+		{
+			Golden: "get_fee_refund_totals_by_recipient",
+			Call:   flashbots.FeeRefundTotalsByRecipient(w3.A("0x000000000000000000000000000000000000c0Fe")),
+			WantRet: &flashbots.FeeRefundTotalsResponse{
+				Pending:  w3.I("1693684675071586282"),
+				Received: w3.I("2166130739230939783"),
+			},
+		},
+	})
+}
+
+func TestFeeRefunds(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*flashbots.FeeRefundsResponse]{
+		{
+			Golden:  "get_fee_refunds_by_hash",
+			Call:    flashbots.FeeRefundsByHash(w3.H("0x669b4704a7d993a946cdd6e2f95233f308ce0c4649d2e04944e8299efcaa098a")),
+			WantRet: &flashbots.FeeRefundsResponse{Refunds: []*flashbots.FeeRefund{feeRefund}},
+		},
+		{
+			Golden: "get_fee_refunds_by_recipient",
+			Call:   flashbots.FeeRefundsByRecipient(w3.A("0x000000000000000000000000000000000000c0Fe"), "0x1"),
+			WantRet: &flashbots.FeeRefundsResponse{
+				Refunds: []*flashbots.FeeRefund{feeRefund},
+				Cursor:  "0x2",
+			},
+		},
+		{
+			Golden: "get_fee_refunds_by_bundle",
+			Call:   flashbots.FeeRefundsByBundle(w3.H("0x2228f5d8954ce31dc1601a8ba264dbd401bf1428388ce88238932815c5d6f23f"), ""),
+			WantRet: &flashbots.FeeRefundsResponse{
+				Refunds: []*flashbots.FeeRefund{{
+					Hash:        w3.H("0x2228f5d8954ce31dc1601a8ba264dbd401bf1428388ce88238932815c5d6f23f"),
+					Amount:      w3.I("0.01234 ether"),
+					BlockNumber: 18_000_000,
+					Status:      flashbots.FeeRefundPending,
+					Recipient:   w3.A("0x000000000000000000000000000000000000c0Fe"),
+				}},
+			},
+		},
+		{
+			Golden:  "get_fee_refunds_by_block",
+			Call:    flashbots.FeeRefundsByBlock(big.NewInt(18_000_000), ""),
+			WantRet: &flashbots.FeeRefundsResponse{Refunds: []*flashbots.FeeRefund{feeRefund}},
+		},
+	})
+}
+
+func TestSetFeeRefundRecipient(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*flashbots.SetFeeRefundRecipientResponse]{
+		{
+			Golden: "set_fee_refund_recipient",
+			Call: flashbots.SetFeeRefundRecipient(
+				w3.A("0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"),
+				w3.A("0x000000000000000000000000000000000000c0Fe"),
+			),
+			WantRet: &flashbots.SetFeeRefundRecipientResponse{
+				From: w3.A("0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"),
+				To:   w3.A("0x000000000000000000000000000000000000c0Fe"),
+			},
+		},
+	})
+}
+
+func TestFeeRefundsIter(t *testing.T) {
+	// serve three pages with two refunds each
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Params []struct {
+				Cursor string `json:"cursor"`
+			} `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var page int
+		fmt.Sscanf(req.Params[0].Cursor, "0x%x", &page)
+		cursor := fmt.Sprintf("0x%x", page+1)
+		if page >= 2 {
+			cursor = ""
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"refunds":[{"blockNumber":"0x%x"},{"blockNumber":"0x%x"}],"cursor":%q}}`,
+			req.ID, 2*page, 2*page+1, cursor,
+		)
+	}))
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL)
+	defer client.Close()
+
+	page := func(cursor string) w3types.RPCCallerFactory[*flashbots.FeeRefundsResponse] {
+		return flashbots.FeeRefundsByRecipient(w3.A("0x000000000000000000000000000000000000c0Fe"), cursor)
+	}
+
+	t.Run("all", func(t *testing.T) {
+		var gotBlockNumbers []uint64
+		for refund, err := range flashbots.FeeRefunds(context.Background(), client, page) {
+			if err != nil {
+				t.Fatalf("Failed to iterate: %v", err)
+			}
+			gotBlockNumbers = append(gotBlockNumbers, refund.BlockNumber)
+		}
+
+		wantBlockNumbers := []uint64{0, 1, 2, 3, 4, 5}
+		if diff := cmp.Diff(wantBlockNumbers, gotBlockNumbers); diff != "" {
+			t.Fatalf("(-want, +got)\n%s", diff)
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		var n int
+		for _, err := range flashbots.FeeRefunds(context.Background(), client, page) {
+			if err != nil {
+				t.Fatalf("Failed to iterate: %v", err)
+			}
+			if n++; n == 3 {
+				break
+			}
+		}
+		if n != 3 {
+			t.Fatalf("Want 3 refunds, got %d", n)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var gotErr error
+		for _, err := range flashbots.FeeRefunds(ctx, client, page) {
+			gotErr = err
+		}
+		if gotErr == nil {
+			t.Fatal("Want error")
+		}
+	})
+	t.Run("null_page", func(t *testing.T) {
+		// serve one page with a cursor followed by a null page
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				ID     json.RawMessage `json:"id"`
+				Params []struct {
+					Cursor string `json:"cursor"`
+				} `json:"params"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+
+			w.Header().Set("Content-Type", "application/json")
+			if req.Params[0].Cursor == "" {
+				fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"refunds":[{"blockNumber":"0x0"}],"cursor":"0x1"}}`, req.ID)
+				return
+			}
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":null}`, req.ID)
+		}))
+		defer srv.Close()
+
+		client := w3.MustDial(srv.URL)
+		defer client.Close()
+
+		var n int
+		for _, err := range flashbots.FeeRefunds(context.Background(), client, page) {
+			if err != nil {
+				t.Fatalf("Failed to iterate: %v", err)
+			}
+			n++
+		}
+		if n != 1 {
+			t.Fatalf("Want 1 refund, got %d", n)
+		}
+	})
+}
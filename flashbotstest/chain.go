@@ -0,0 +1,110 @@
+package flashbotstest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lmittmann/flashbots"
+)
+
+// Chain is a fake chain that implements the [flashbots.ChainSource]. Blocks are
+// mined manually with [Chain.AddBlock]. The genesis block has the timestamp 0
+// and every following block is mined 12 seconds after its parent.
+type Chain struct {
+	mux    sync.Mutex
+	blocks []*types.Block
+	nonces []map[common.Address]uint64 // nonces after each block
+	feed   event.Feed
+}
+
+var _ flashbots.ChainSource = (*Chain)(nil)
+
+// NewChain returns a new [Chain] that only contains the genesis block.
+func NewChain() *Chain {
+	genesis := types.NewBlockWithHeader(&types.Header{
+		Number:     new(big.Int),
+		Difficulty: new(big.Int),
+	})
+	return &Chain{
+		blocks: []*types.Block{genesis},
+		nonces: []map[common.Address]uint64{{}},
+	}
+}
+
+// AddBlock mines a new block with the given txs and sends its header to all
+// subscribers. AddBlock blocks until all subscribers received the header.
+func (c *Chain) AddBlock(txs ...*types.Transaction) *types.Block {
+	c.mux.Lock()
+	parent := c.blocks[len(c.blocks)-1]
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number(), big.NewInt(1)),
+		Time:       parent.Time() + 12,
+		Difficulty: new(big.Int),
+	}
+	block := types.NewBlockWithHeader(header).WithBody(types.Body{Transactions: txs})
+
+	nonces := maps.Clone(c.nonces[len(c.nonces)-1])
+	for _, tx := range txs {
+		from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			panic("flashbotstest: " + err.Error())
+		}
+		nonces[from] = tx.Nonce() + 1
+	}
+
+	c.blocks = append(c.blocks, block)
+	c.nonces = append(c.nonces, nonces)
+	c.mux.Unlock()
+
+	c.feed.Send(block.Header())
+	return block
+}
+
+// SubscribeNewHeads implements the [flashbots.ChainSource].
+func (c *Chain) SubscribeNewHeads(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return c.feed.Subscribe(ch), nil
+}
+
+// BlockByNumber implements the [flashbots.ChainSource]. The latest block is
+// returned if number is nil.
+func (c *Chain) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	i, err := c.index(number)
+	if err != nil {
+		return nil, err
+	}
+	return c.blocks[i], nil
+}
+
+// NonceAt implements the [flashbots.ChainSource]. The nonce at the latest block
+// is returned if number is nil.
+func (c *Chain) NonceAt(ctx context.Context, addr common.Address, number *big.Int) (uint64, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	i, err := c.index(number)
+	if err != nil {
+		return 0, err
+	}
+	return c.nonces[i][addr], nil
+}
+
+func (c *Chain) index(number *big.Int) (int, error) {
+	if number == nil {
+		return len(c.blocks) - 1, nil
+	}
+	if !number.IsInt64() || number.Sign() < 0 || number.Int64() >= int64(len(c.blocks)) {
+		return 0, fmt.Errorf("flashbotstest: block %v not found", number)
+	}
+	return int(number.Int64()), nil
+}
@@ -0,0 +1,537 @@
+/*
+Package flashbotstest provides an in-process mock of the Flashbots relay for
+end-to-end tests.
+
+The mock relay implements eth_sendBundle, eth_callBundle, eth_cancelBundle,
+eth_sendPrivateTransaction, eth_sendPrivateRawTransaction,
+eth_cancelPrivateTransaction, flashbots_getUserStats,
+flashbots_getUserStatsV2, flashbots_getBundleStats, flashbots_getBundleStatsV2,
+and flashbots_setFeeRefundRecipient with in-memory state.
+Every request must be signed with a valid 'X-Flashbots-Signature' header (see
+[flashbots.AuthTransport]).
+
+The fake [Chain] implements the [flashbots.ChainSource] to track bundles
+offline.
+*/
+package flashbotstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/lmittmann/flashbots"
+)
+
+// HandlerFunc handles a request with the given positional params that was
+// signed by signer. A returned error of type [*Error] is sent as is, any other
+// error is sent with code -32000.
+type HandlerFunc func(signer common.Address, params []json.RawMessage) (result any, err error)
+
+// Error is a JSON-RPC error.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// ErrorCode implements the [rpc.Error].
+func (e *Error) ErrorCode() int { return e.Code }
+
+// Bundle is a bundle received via eth_sendBundle.
+type Bundle struct {
+	Signer     common.Address
+	Hash       common.Hash
+	ReceivedAt time.Time
+	Canceled   bool
+	Request    *flashbots.SendBundleRequest
+}
+
+// CallBundle is a bundle received via eth_callBundle.
+type CallBundle struct {
+	Signer  common.Address
+	Request *flashbots.CallBundleRequest
+}
+
+// PrivateTx is a private transaction received via eth_sendPrivateTransaction
+// or eth_sendPrivateRawTransaction.
+type PrivateTx struct {
+	Signer   common.Address
+	Hash     common.Hash
+	Canceled bool
+	Request  *flashbots.SendPrivateTxRequest
+}
+
+// Server is a mock Flashbots relay.
+type Server struct {
+	srv *httptest.Server
+
+	mux              sync.Mutex
+	handlers         map[string]HandlerFunc
+	bundles          []*Bundle
+	callBundles      []*CallBundle
+	privateTxs       []*PrivateTx
+	highPriority     map[common.Address]bool
+	refundRecipients map[common.Address]common.Address
+}
+
+// NewServer starts and returns a new mock Flashbots relay. The caller should
+// call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		handlers:         make(map[string]HandlerFunc),
+		highPriority:     make(map[common.Address]bool),
+		refundRecipients: make(map[common.Address]common.Address),
+	}
+	s.handlers["eth_sendBundle"] = s.sendBundle
+	s.handlers["eth_callBundle"] = s.callBundle
+	s.handlers["eth_cancelBundle"] = s.cancelBundle
+	s.handlers["eth_sendPrivateTransaction"] = s.sendPrivateTx
+	s.handlers["eth_sendPrivateRawTransaction"] = s.sendPrivateRawTx
+	s.handlers["eth_cancelPrivateTransaction"] = s.cancelPrivateTx
+	s.handlers["flashbots_getUserStats"] = s.userStats
+	s.handlers["flashbots_getUserStatsV2"] = s.userStatsV2
+	s.handlers["flashbots_getBundleStats"] = s.bundleStats
+	s.handlers["flashbots_getBundleStatsV2"] = s.bundleStatsV2
+	s.handlers["flashbots_setFeeRefundRecipient"] = s.setFeeRefundRecipient
+
+	s.srv = httptest.NewServer(s)
+	return s
+}
+
+// URL returns the RPC endpoint URL of the server.
+func (s *Server) URL() string { return s.srv.URL }
+
+// Close shuts down the server.
+func (s *Server) Close() { s.srv.Close() }
+
+// HandleFunc registers the handler for the given method. It replaces the
+// default handler of the method, which allows scripting responses and errors.
+func (s *Server) HandleFunc(method string, handler HandlerFunc) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.handlers[method] = handler
+}
+
+// SetHighPriority sets whether the searcher with the given address is in the
+// high priority queue.
+func (s *Server) SetHighPriority(addr common.Address, highPriority bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.highPriority[addr] = highPriority
+}
+
+// FeeRefundRecipient returns the recipient of the gas fee refunds of the given
+// address, as set via flashbots_setFeeRefundRecipient. The address itself is
+// returned if no recipient was set.
+func (s *Server) FeeRefundRecipient(addr common.Address) common.Address {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if recipient, ok := s.refundRecipients[addr]; ok {
+		return recipient
+	}
+	return addr
+}
+
+// Bundles returns all bundles received via eth_sendBundle.
+func (s *Server) Bundles() []*Bundle {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return append([]*Bundle(nil), s.bundles...)
+}
+
+// CallBundles returns all bundles received via eth_callBundle.
+func (s *Server) CallBundles() []*CallBundle {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return append([]*CallBundle(nil), s.callBundles...)
+}
+
+// PrivateTxs returns all private transactions received via
+// eth_sendPrivateTransaction.
+func (s *Server) PrivateTxs() []*PrivateTx {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return append([]*PrivateTx(nil), s.privateTxs...)
+}
+
+type request struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		writeResponse(w, nil, nil, &Error{Code: -32600, Message: "batch requests are not supported"})
+		return
+	}
+
+	var req request
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeResponse(w, nil, nil, &Error{Code: -32700, Message: "parse error"})
+		return
+	}
+
+	signer, err := flashbots.VerifySignature(r.Header.Get("X-Flashbots-Signature"), body)
+	if err != nil {
+		writeResponse(w, req.ID, nil, &Error{Code: -32600, Message: err.Error()})
+		return
+	}
+
+	s.mux.Lock()
+	handler, ok := s.handlers[req.Method]
+	s.mux.Unlock()
+	if !ok {
+		writeResponse(w, req.ID, nil, &Error{Code: -32601, Message: fmt.Sprintf("the method %s does not exist/is not available", req.Method)})
+		return
+	}
+
+	result, err := handler(signer, req.Params)
+	if err != nil {
+		var rpcErr *Error
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &Error{Code: -32000, Message: err.Error()}
+		}
+		writeResponse(w, req.ID, nil, rpcErr)
+		return
+	}
+	writeResponse(w, req.ID, result, nil)
+}
+
+func writeResponse(w http.ResponseWriter, id json.RawMessage, result any, rpcErr *Error) {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	resp := &response{JSONRPC: "2.0", ID: id, Error: rpcErr}
+	if rpcErr == nil {
+		var err error
+		if resp.Result, err = json.Marshal(result); err != nil {
+			resp.Result, resp.Error = nil, &Error{Code: -32603, Message: err.Error()}
+		}
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func decodeParam(params []json.RawMessage, v any) error {
+	if len(params) < 1 {
+		return &Error{Code: -32602, Message: "missing value for required argument 0"}
+	}
+	if err := json.Unmarshal(params[0], v); err != nil {
+		return &Error{Code: -32602, Message: fmt.Sprintf("invalid argument 0: %v", err)}
+	}
+	return nil
+}
+
+func (s *Server) sendBundle(signer common.Address, params []json.RawMessage) (any, error) {
+	r := new(flashbots.SendBundleRequest)
+	if err := decodeParam(params, r); err != nil {
+		return nil, err
+	}
+	if len(r.RawTransactions) <= 0 {
+		return nil, errors.New("bundle missing txs")
+	}
+	if r.BlockNumber == nil {
+		return nil, errors.New("bundle missing blockNumber")
+	}
+	txs := make(types.Transactions, len(r.RawTransactions))
+	for i, rawTx := range r.RawTransactions {
+		txs[i] = new(types.Transaction)
+		if err := txs[i].UnmarshalBinary(rawTx); err != nil {
+			return nil, fmt.Errorf("invalid transaction %d: %w", i, err)
+		}
+	}
+
+	bundle := &Bundle{
+		Signer:     signer,
+		Hash:       flashbots.BundleHash(txs),
+		ReceivedAt: time.Now(),
+		Request:    r,
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.bundles = append(s.bundles, bundle)
+	return map[string]common.Hash{"bundleHash": bundle.Hash}, nil
+}
+
+func (s *Server) cancelBundle(signer common.Address, params []json.RawMessage) (any, error) {
+	var req struct {
+		ReplacementUuid uuid.UUID `json:"replacementUuid"`
+	}
+	if err := decodeParam(params, &req); err != nil {
+		return nil, err
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for _, bundle := range s.bundles {
+		if bundle.Signer == signer && bundle.Request.ReplacementUuid == req.ReplacementUuid {
+			bundle.Canceled = true
+		}
+	}
+	return true, nil
+}
+
+func (s *Server) callBundle(signer common.Address, params []json.RawMessage) (any, error) {
+	r := new(flashbots.CallBundleRequest)
+	if err := decodeParam(params, r); err != nil {
+		return nil, err
+	}
+
+	s.mux.Lock()
+	s.callBundles = append(s.callBundles, &CallBundle{Signer: signer, Request: r})
+	s.mux.Unlock()
+
+	// Every transaction is assumed to use all of its gas and pay only its
+	// priority fee to the coinbase.
+	var (
+		txs          = make(types.Transactions, len(r.RawTransactions))
+		results      = make([]map[string]any, len(r.RawTransactions))
+		totalGasUsed uint64
+		totalFees    = new(big.Int)
+	)
+	for i, rawTx := range r.RawTransactions {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(rawTx); err != nil {
+			return nil, fmt.Errorf("invalid transaction %d: %w", i, err)
+		}
+		txs[i] = tx
+		from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction %d: %w", i, err)
+		}
+
+		gasFees := new(big.Int).Mul(tx.GasTipCap(), new(big.Int).SetUint64(tx.Gas()))
+		results[i] = map[string]any{
+			"coinbaseDiff":      gasFees.String(),
+			"ethSentToCoinbase": "0",
+			"fromAddress":       from,
+			"gasFees":           gasFees.String(),
+			"gasPrice":          tx.GasTipCap().String(),
+			"gasUsed":           tx.Gas(),
+			"toAddress":         tx.To(),
+			"txHash":            tx.Hash(),
+			"value":             "0x",
+		}
+		totalGasUsed += tx.Gas()
+		totalFees.Add(totalFees, gasFees)
+	}
+
+	bundleGasPrice := new(big.Int)
+	if totalGasUsed > 0 {
+		bundleGasPrice.Div(totalFees, new(big.Int).SetUint64(totalGasUsed))
+	}
+	stateBlockNumber := new(big.Int)
+	if r.BlockNumber != nil && r.BlockNumber.Sign() > 0 {
+		stateBlockNumber.Sub(r.BlockNumber, big.NewInt(1))
+	}
+	return map[string]any{
+		"bundleGasPrice":    bundleGasPrice.String(),
+		"bundleHash":        flashbots.BundleHash(txs),
+		"coinbaseDiff":      totalFees.String(),
+		"ethSentToCoinbase": "0",
+		"gasFees":           totalFees.String(),
+		"results":           results,
+		"stateBlockNumber":  stateBlockNumber,
+		"totalGasUsed":      totalGasUsed,
+	}, nil
+}
+
+func (s *Server) sendPrivateTx(signer common.Address, params []json.RawMessage) (any, error) {
+	r := new(flashbots.SendPrivateTxRequest)
+	if err := decodeParam(params, r); err != nil {
+		return nil, err
+	}
+	return s.addPrivateTx(signer, r)
+}
+
+func (s *Server) sendPrivateRawTx(signer common.Address, params []json.RawMessage) (any, error) {
+	var rawTx hexutil.Bytes
+	if err := decodeParam(params, &rawTx); err != nil {
+		return nil, err
+	}
+
+	// decode the positional params as eth_sendPrivateTransaction request
+	req := map[string]json.RawMessage{"tx": params[0]}
+	if len(params) > 1 {
+		req["preferences"] = params[1]
+	}
+	input, _ := json.Marshal(req)
+	r := new(flashbots.SendPrivateTxRequest)
+	if err := json.Unmarshal(input, r); err != nil {
+		return nil, &Error{Code: -32602, Message: fmt.Sprintf("invalid argument 1: %v", err)}
+	}
+	return s.addPrivateTx(signer, r)
+}
+
+func (s *Server) addPrivateTx(signer common.Address, r *flashbots.SendPrivateTxRequest) (any, error) {
+	if len(r.RawTx) <= 0 {
+		return nil, errors.New("missing tx")
+	}
+
+	privateTx := &PrivateTx{
+		Signer:  signer,
+		Hash:    crypto.Keccak256Hash(r.RawTx),
+		Request: r,
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.privateTxs = append(s.privateTxs, privateTx)
+	return privateTx.Hash, nil
+}
+
+func (s *Server) cancelPrivateTx(signer common.Address, params []json.RawMessage) (any, error) {
+	var req struct {
+		TxHash common.Hash `json:"txHash"`
+	}
+	if err := decodeParam(params, &req); err != nil {
+		return nil, err
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for _, privateTx := range s.privateTxs {
+		if privateTx.Signer == signer && privateTx.Hash == req.TxHash && !privateTx.Canceled {
+			privateTx.Canceled = true
+			return true, nil
+		}
+	}
+	return nil, errors.New("tx not found")
+}
+
+func (s *Server) setFeeRefundRecipient(signer common.Address, params []json.RawMessage) (any, error) {
+	if len(params) < 2 {
+		return nil, &Error{Code: -32602, Message: "missing value for required argument 1"}
+	}
+	var delegate, recipient common.Address
+	if err := json.Unmarshal(params[0], &delegate); err != nil {
+		return nil, &Error{Code: -32602, Message: fmt.Sprintf("invalid argument 0: %v", err)}
+	}
+	if err := json.Unmarshal(params[1], &recipient); err != nil {
+		return nil, &Error{Code: -32602, Message: fmt.Sprintf("invalid argument 1: %v", err)}
+	}
+	if delegate != signer {
+		return nil, errors.New("signer does not match delegate")
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.refundRecipients[delegate] = recipient
+	return map[string]any{"from": delegate, "to": recipient}, nil
+}
+
+func (s *Server) userStats(signer common.Address, params []json.RawMessage) (any, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return map[string]any{
+		"is_high_priority":        s.highPriority[signer],
+		"all_time_miner_payments": "0",
+		"all_time_gas_simulated":  "0",
+		"last_7d_miner_payments":  "0",
+		"last_7d_gas_simulated":   "0",
+		"last_1d_miner_payments":  "0",
+		"last_1d_gas_simulated":   "0",
+	}, nil
+}
+
+func (s *Server) userStatsV2(signer common.Address, params []json.RawMessage) (any, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return map[string]any{
+		"isHighPriority":           s.highPriority[signer],
+		"allTimeValidatorPayments": "0",
+		"allTimeGasSimulated":      "0",
+		"last7dValidatorPayments":  "0",
+		"last7dGasSimulated":       "0",
+		"last1dValidatorPayments":  "0",
+		"last1dGasSimulated":       "0",
+	}, nil
+}
+
+func (s *Server) bundleStats(signer common.Address, params []json.RawMessage) (any, error) {
+	bundle, err := s.findBundle(signer, params)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return map[string]any{
+		"isSimulated":    true,
+		"isSentToMiners": true,
+		"isHighPriority": s.highPriority[signer],
+		"simulatedAt":    bundle.ReceivedAt,
+		"submittedAt":    bundle.ReceivedAt,
+		"sentToMinersAt": bundle.ReceivedAt,
+	}, nil
+}
+
+func (s *Server) bundleStatsV2(signer common.Address, params []json.RawMessage) (any, error) {
+	bundle, err := s.findBundle(signer, params)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return map[string]any{
+		"isHighPriority":         s.highPriority[signer],
+		"isSimulated":            true,
+		"simulatedAt":            bundle.ReceivedAt,
+		"receivedAt":             bundle.ReceivedAt,
+		"consideredByBuildersAt": []any{},
+		"sealedByBuildersAt":     []any{},
+	}, nil
+}
+
+// findBundle returns the bundle of the signer with the bundle hash and block
+// number of the stats request in params.
+func (s *Server) findBundle(signer common.Address, params []json.RawMessage) (*Bundle, error) {
+	var req struct {
+		BundleHash  common.Hash  `json:"bundleHash"`
+		BlockNumber *hexutil.Big `json:"blockNumber"`
+	}
+	if err := decodeParam(params, &req); err != nil {
+		return nil, err
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for _, bundle := range s.bundles {
+		if bundle.Signer != signer || bundle.Hash != req.BundleHash ||
+			req.BlockNumber != nil && bundle.Request.BlockNumber.Cmp(req.BlockNumber.ToInt()) != 0 {
+			continue
+		}
+		return bundle, nil
+	}
+	return nil, errors.New("bundle not found")
+}
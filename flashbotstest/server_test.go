@@ -0,0 +1,346 @@
+package flashbotstest_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/lmittmann/flashbots"
+	"github.com/lmittmann/flashbots/flashbotstest"
+	"github.com/lmittmann/w3"
+)
+
+var (
+	prv, _ = crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+	addr   = crypto.PubkeyToAddress(prv.PublicKey)
+	signer = types.LatestSigner(params.MainnetChainConfig)
+)
+
+func TestServerSendBundle(t *testing.T) {
+	srv := flashbotstest.NewServer()
+	defer srv.Close()
+
+	client := flashbots.MustDial(srv.URL(), prv)
+	defer client.Close()
+
+	tx := types.MustSignNewTx(prv, signer, &types.DynamicFeeTx{
+		ChainID:   params.MainnetChainConfig.ChainID,
+		GasTipCap: w3.I("1 gwei"),
+		GasFeeCap: w3.I("10 gwei"),
+		Gas:       21_000,
+		To:        &addr,
+	})
+	rawTx, _ := tx.MarshalBinary()
+	replacementUuid := uuid.MustParse("2c9cf5d0-f13c-4b7a-b51d-f462fdb27b51")
+
+	var (
+		bundleHash  common.Hash
+		bundleStats *flashbots.BundleStatsV2Response
+		canceled    bool
+	)
+	if err := client.Call(flashbots.SendBundle(&flashbots.SendBundleRequest{
+		Transactions:    types.Transactions{tx},
+		BlockNumber:     big.NewInt(9_999_999),
+		ReplacementUuid: replacementUuid,
+	}).Returns(&bundleHash)); err != nil {
+		t.Fatalf("Failed to send bundle: %v", err)
+	}
+	if err := client.Call(flashbots.BundleStatsV2(bundleHash, big.NewInt(9_999_999)).Returns(&bundleStats)); err != nil {
+		t.Fatalf("Failed to get bundle stats: %v", err)
+	}
+	if !bundleStats.IsSimulated {
+		t.Fatal("Bundle not simulated")
+	}
+	if err := client.Call(flashbots.CancelBundle(replacementUuid).Returns(&canceled)); err != nil {
+		t.Fatalf("Failed to cancel bundle: %v", err)
+	}
+
+	wantBundleHash := crypto.Keccak256Hash(tx.Hash().Bytes())
+	if bundleHash != wantBundleHash {
+		t.Fatalf("Bundle hash: want %s, got %s", wantBundleHash, bundleHash)
+	}
+
+	bundles := srv.Bundles()
+	if len(bundles) != 1 {
+		t.Fatalf("Want 1 bundle, got %d", len(bundles))
+	}
+	if diff := cmp.Diff(
+		&flashbotstest.Bundle{
+			Signer:   addr,
+			Hash:     wantBundleHash,
+			Canceled: true,
+			Request: &flashbots.SendBundleRequest{
+				RawTransactions: [][]byte{rawTx},
+				BlockNumber:     big.NewInt(9_999_999),
+				ReplacementUuid: replacementUuid,
+			},
+		},
+		bundles[0],
+		cmp.AllowUnexported(big.Int{}),
+		cmp.FilterPath(func(p cmp.Path) bool { return p.Last().String() == ".ReceivedAt" }, cmp.Ignore()),
+	); diff != "" {
+		t.Fatalf("(-want, +got)\n%s", diff)
+	}
+}
+
+func TestServerCallBundle(t *testing.T) {
+	srv := flashbotstest.NewServer()
+	defer srv.Close()
+
+	client := flashbots.MustDial(srv.URL(), prv)
+	defer client.Close()
+
+	tx := types.MustSignNewTx(prv, signer, &types.DynamicFeeTx{
+		ChainID:   params.MainnetChainConfig.ChainID,
+		GasTipCap: w3.I("1 gwei"),
+		GasFeeCap: w3.I("10 gwei"),
+		Gas:       21_000,
+		To:        &addr,
+	})
+
+	var resp *flashbots.CallBundleResponse
+	if err := client.Call(flashbots.CallBundle(&flashbots.CallBundleRequest{
+		Transactions: types.Transactions{tx},
+		BlockNumber:  big.NewInt(9_999_999),
+	}).Returns(&resp)); err != nil {
+		t.Fatalf("Failed to call bundle: %v", err)
+	}
+
+	if resp.TotalGasUsed != 21_000 || len(resp.Results) != 1 || resp.Results[0].FromAddress != addr {
+		t.Fatalf("Unexpected response: %+v", resp)
+	}
+	if len(srv.CallBundles()) != 1 {
+		t.Fatalf("Want 1 call bundle, got %d", len(srv.CallBundles()))
+	}
+}
+
+func TestServerPrivateTx(t *testing.T) {
+	srv := flashbotstest.NewServer()
+	defer srv.Close()
+
+	client := flashbots.MustDial(srv.URL(), prv)
+	defer client.Close()
+
+	var (
+		txHash   common.Hash
+		canceled bool
+	)
+	if err := client.Call(flashbots.SendPrivateTx(&flashbots.SendPrivateTxRequest{
+		RawTx:          w3.B("0x00"),
+		MaxBlockNumber: big.NewInt(9_999_999),
+		Fast:           true,
+		Hints:          []flashbots.Hint{flashbots.HintHash},
+		Refunds:        []flashbots.ProtectRefund{{Address: addr, Percent: 90}},
+	}).Returns(&txHash)); err != nil {
+		t.Fatalf("Failed to send private tx: %v", err)
+	}
+	if err := client.Call(flashbots.CancelPrivateTx(txHash).Returns(&canceled)); err != nil {
+		t.Fatalf("Failed to cancel private tx: %v", err)
+	}
+	if !canceled {
+		t.Fatal("Private tx not canceled")
+	}
+
+	privateTxs := srv.PrivateTxs()
+	if len(privateTxs) != 1 || !privateTxs[0].Canceled || !privateTxs[0].Request.Fast {
+		t.Fatalf("Unexpected private txs: %+v", privateTxs)
+	}
+	if diff := cmp.Diff(
+		&flashbots.SendPrivateTxRequest{
+			RawTx:          w3.B("0x00"),
+			MaxBlockNumber: big.NewInt(9_999_999),
+			Fast:           true,
+			Hints:          []flashbots.Hint{flashbots.HintHash},
+			Refunds:        []flashbots.ProtectRefund{{Address: addr, Percent: 90}},
+		},
+		privateTxs[0].Request,
+		cmp.AllowUnexported(big.Int{}),
+	); diff != "" {
+		t.Fatalf("(-want, +got)\n%s", diff)
+	}
+}
+
+func TestServerPrivateRawTx(t *testing.T) {
+	srv := flashbotstest.NewServer()
+	defer srv.Close()
+
+	client := flashbots.MustDial(srv.URL(), prv)
+	defer client.Close()
+
+	var txHash common.Hash
+	if err := client.Call(flashbots.SendPrivateRawTx(&flashbots.SendPrivateTxRequest{
+		RawTx:    w3.B("0x00"),
+		Builders: []string{"flashbots"},
+	}).Returns(&txHash)); err != nil {
+		t.Fatalf("Failed to send private raw tx: %v", err)
+	}
+
+	privateTxs := srv.PrivateTxs()
+	if len(privateTxs) != 1 || privateTxs[0].Hash != txHash {
+		t.Fatalf("Unexpected private txs: %+v", privateTxs)
+	}
+	if diff := cmp.Diff(
+		&flashbots.SendPrivateTxRequest{
+			RawTx:    w3.B("0x00"),
+			Builders: []string{"flashbots"},
+		},
+		privateTxs[0].Request,
+	); diff != "" {
+		t.Fatalf("(-want, +got)\n%s", diff)
+	}
+}
+
+func TestServerSetFeeRefundRecipient(t *testing.T) {
+	srv := flashbotstest.NewServer()
+	defer srv.Close()
+
+	client := flashbots.MustDial(srv.URL(), prv)
+	defer client.Close()
+
+	recipient := w3.A("0x000000000000000000000000000000000000c0Fe")
+	if got := srv.FeeRefundRecipient(addr); got != addr {
+		t.Fatalf("Default recipient: want %s, got %s", addr, got)
+	}
+
+	var resp *flashbots.SetFeeRefundRecipientResponse
+	if err := client.Call(flashbots.SetFeeRefundRecipient(addr, recipient).Returns(&resp)); err != nil {
+		t.Fatalf("Failed to set fee refund recipient: %v", err)
+	}
+	if resp.From != addr || resp.To != recipient {
+		t.Fatalf("Unexpected response: %+v", resp)
+	}
+	if got := srv.FeeRefundRecipient(addr); got != recipient {
+		t.Fatalf("Recipient: want %s, got %s", recipient, got)
+	}
+
+	// delegate must be the signer
+	if err := client.Call(flashbots.SetFeeRefundRecipient(recipient, addr).Returns(&resp)); err == nil {
+		t.Fatal("Want error")
+	}
+}
+
+func TestServerUserStatsV2(t *testing.T) {
+	srv := flashbotstest.NewServer()
+	defer srv.Close()
+	srv.SetHighPriority(addr, true)
+
+	client := flashbots.MustDial(srv.URL(), prv)
+	defer client.Close()
+
+	var userStats *flashbots.UserStatsV2Response
+	if err := client.Call(flashbots.UserStatsV2(big.NewInt(9_999_999)).Returns(&userStats)); err != nil {
+		t.Fatalf("Failed to get user stats: %v", err)
+	}
+	if !userStats.IsHighPriority {
+		t.Fatal("Searcher not high priority")
+	}
+}
+
+func TestServerUserStats(t *testing.T) {
+	srv := flashbotstest.NewServer()
+	defer srv.Close()
+	srv.SetHighPriority(addr, true)
+
+	client := flashbots.MustDial(srv.URL(), prv)
+	defer client.Close()
+
+	var userStats *flashbots.UserStatsResponse
+	if err := client.Call(flashbots.UserStats(big.NewInt(9_999_999)).Returns(&userStats)); err != nil {
+		t.Fatalf("Failed to get user stats: %v", err)
+	}
+	if !userStats.IsHighPriority || userStats.AllTimeMinerPayments == nil {
+		t.Fatalf("Unexpected user stats: %+v", userStats)
+	}
+}
+
+func TestServerBundleStats(t *testing.T) {
+	srv := flashbotstest.NewServer()
+	defer srv.Close()
+
+	client := flashbots.MustDial(srv.URL(), prv)
+	defer client.Close()
+
+	tx := types.MustSignNewTx(prv, signer, &types.DynamicFeeTx{
+		ChainID:   params.MainnetChainConfig.ChainID,
+		GasTipCap: w3.I("1 gwei"),
+		GasFeeCap: w3.I("10 gwei"),
+		Gas:       21_000,
+		To:        &addr,
+	})
+
+	var (
+		bundleHash  common.Hash
+		bundleStats *flashbots.BundleStatsResponse
+	)
+	if err := client.Call(flashbots.SendBundle(&flashbots.SendBundleRequest{
+		Transactions: types.Transactions{tx},
+		BlockNumber:  big.NewInt(9_999_999),
+	}).Returns(&bundleHash)); err != nil {
+		t.Fatalf("Failed to send bundle: %v", err)
+	}
+	if err := client.Call(flashbots.BundleStats(bundleHash, big.NewInt(9_999_999)).Returns(&bundleStats)); err != nil {
+		t.Fatalf("Failed to get bundle stats: %v", err)
+	}
+	if !bundleStats.IsSimulated || !bundleStats.IsSentToMiners || bundleStats.SubmittedAt.IsZero() {
+		t.Fatalf("Unexpected bundle stats: %+v", bundleStats)
+	}
+
+	// unknown bundle
+	if err := client.Call(flashbots.BundleStats(common.Hash{}, big.NewInt(9_999_999)).Returns(&bundleStats)); err == nil {
+		t.Fatal("Want error")
+	}
+}
+
+func TestServerHandleFunc(t *testing.T) {
+	srv := flashbotstest.NewServer()
+	defer srv.Close()
+	srv.HandleFunc("eth_sendBundle", func(signer common.Address, params []json.RawMessage) (any, error) {
+		return nil, &flashbotstest.Error{Code: -32000, Message: "rate limited"}
+	})
+
+	client := flashbots.MustDial(srv.URL(), prv)
+	defer client.Close()
+
+	var bundleHash common.Hash
+	err := client.Call(flashbots.SendBundle(&flashbots.SendBundleRequest{
+		RawTransactions: [][]byte{w3.B("0x00")},
+		BlockNumber:     big.NewInt(9_999_999),
+	}).Returns(&bundleHash))
+
+	var (
+		callErrs w3.CallErrors
+		rpcErr   rpc.Error
+	)
+	if !errors.As(err, &callErrs) || !errors.As(callErrs[0], &rpcErr) ||
+		rpcErr.ErrorCode() != -32000 || rpcErr.Error() != "rate limited" {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestServerUnsigned(t *testing.T) {
+	srv := flashbotstest.NewServer()
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL())
+	defer client.Close()
+
+	var bundleHash common.Hash
+	err := client.Call(flashbots.SendBundle(&flashbots.SendBundleRequest{
+		RawTransactions: [][]byte{w3.B("0x00")},
+		BlockNumber:     big.NewInt(9_999_999),
+	}).Returns(&bundleHash))
+	if want := "w3: call failed: flashbots: invalid signature: missing header"; err == nil || err.Error() != want {
+		t.Fatalf("Err: want %q, got %v", want, err)
+	}
+	if len(srv.Bundles()) != 0 {
+		t.Fatal("Unsigned bundle was recorded")
+	}
+}
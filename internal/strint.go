@@ -5,7 +5,8 @@ import (
 	"math/big"
 )
 
-// StrInt wraps a big.Int and is marshaled as a string.
+// StrInt wraps a big.Int and is marshaled as a decimal string. Decimal and
+// "0x"-prefixed hex strings are unmarshaled.
 type StrInt big.Int
 
 // MarshalJSON implements the json json.Marshaler interface.
@@ -21,7 +22,14 @@ func (i *StrInt) UnmarshalJSON(input []byte) error {
 	if len(input) == 2 {
 		return nil
 	}
-	_, ok := (*big.Int)(i).SetString(string(input[1:len(input)-1]), 10)
+	str, base := string(input[1:len(input)-1]), 10
+	if len(str) > 2 && (str[:2] == "0x" || str[:2] == "0X") {
+		str, base = str[2:], 16
+	}
+	if base == 16 && (str[0] == '-' || str[0] == '+') {
+		return fmt.Errorf("invalid number string %q", input[1:len(input)-1])
+	}
+	_, ok := (*big.Int)(i).SetString(str, base)
 	if !ok {
 		return fmt.Errorf("invalid number string %q", input[1:len(input)-1])
 	}
@@ -67,6 +67,18 @@ func TestStrBigintUnmarshalJSON(t *testing.T) {
 			JSON:    []byte(`"-1"`),
 			WantInt: StrInt(*big.NewInt(-1)),
 		},
+		{
+			JSON:    []byte(`"0x0"`),
+			WantInt: StrInt(*big.NewInt(0)),
+		},
+		{
+			JSON:    []byte(`"0xff"`),
+			WantInt: StrInt(*big.NewInt(255)),
+		},
+		{
+			JSON:    []byte(`"0x-1"`),
+			WantErr: errors.New(`invalid number string "0x-1"`),
+		},
 		{
 			JSON:    []byte(`0`),
 			WantErr: errors.New("invalid number string 0"),
@@ -0,0 +1,195 @@
+package flashbots
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// Hint is a MEV-Share privacy hint that specifies which data of a transaction
+// or bundle is shared with searchers.
+type Hint string
+
+// MEV-Share privacy hints.
+const (
+	HintCalldata         Hint = "calldata"
+	HintContractAddress  Hint = "contract_address"
+	HintLogs             Hint = "logs"
+	HintFunctionSelector Hint = "function_selector"
+	HintHash             Hint = "hash"
+	HintTxHash           Hint = "tx_hash"
+	HintDefaultLogs      Hint = "default_logs"
+	HintSpecialLogs      Hint = "special_logs"
+)
+
+type MevSendBundleRequest struct {
+	Version   string             // Version of the bundle format, "v0.1" if empty.
+	Inclusion MevBundleInclusion // Block range for which the bundle is valid.
+	Body      []MevBundleBody    // List of transactions, tx hashes, or nested bundles.
+	Validity  *MevBundleValidity // Refund requirements of the bundle (Optional).
+	Privacy   *MevBundlePrivacy  // Data shared with searchers and builders (Optional).
+}
+
+type MevBundleInclusion struct {
+	BlockNumber    *big.Int // Block number for which the bundle is valid.
+	MaxBlockNumber *big.Int // Max block number for which the bundle is valid (Optional).
+}
+
+// MevBundleBody is an element of the body of a MEV-Share bundle. Exactly one of
+// Hash, Tx, RawTx, or Bundle must be set.
+type MevBundleBody struct {
+	Hash      *common.Hash          // Hash of a pending transaction to backrun.
+	Tx        *types.Transaction    // Signed transaction.
+	RawTx     []byte                // Raw signed transaction.
+	CanRevert bool                  // Allow Tx or RawTx to revert.
+	Bundle    *MevSendBundleRequest // Nested bundle.
+}
+
+type MevBundleValidity struct {
+	Refund       []MevBundleRefund       // Minimum refunds per body element.
+	RefundConfig []MevBundleRefundConfig // Distribution of the refund to addresses.
+}
+
+type MevBundleRefund struct {
+	BodyIdx int // Index of the body element that receives the refund.
+	Percent int // Minimum refund percentage.
+}
+
+type MevBundleRefundConfig struct {
+	Address common.Address // Recipient of the refund.
+	Percent int            // Share of the refund in percent.
+}
+
+type MevBundlePrivacy struct {
+	Hints    []Hint   // Data shared with searchers.
+	Builders []string // Builders that may receive the bundle.
+}
+
+type mevSendBundleRequest struct {
+	Version   string             `json:"version"`
+	Inclusion mevBundleInclusion `json:"inclusion"`
+	Body      []mevBundleBody    `json:"body"`
+	Validity  *mevBundleValidity `json:"validity,omitempty"`
+	Privacy   *mevBundlePrivacy  `json:"privacy,omitempty"`
+}
+
+type mevBundleInclusion struct {
+	BlockNumber    *hexutil.Big `json:"block"`
+	MaxBlockNumber *hexutil.Big `json:"maxBlock,omitempty"`
+}
+
+type mevBundleBody struct {
+	Hash      *common.Hash          `json:"hash,omitempty"`
+	Tx        hexutil.Bytes         `json:"tx,omitempty"`
+	CanRevert bool                  `json:"canRevert,omitempty"`
+	Bundle    *MevSendBundleRequest `json:"bundle,omitempty"`
+}
+
+type mevBundleValidity struct {
+	Refund       []mevBundleRefund       `json:"refund,omitempty"`
+	RefundConfig []mevBundleRefundConfig `json:"refundConfig,omitempty"`
+}
+
+type mevBundleRefund struct {
+	BodyIdx int `json:"bodyIdx"`
+	Percent int `json:"percent"`
+}
+
+type mevBundleRefundConfig struct {
+	Address common.Address `json:"address"`
+	Percent int            `json:"percent"`
+}
+
+type mevBundlePrivacy struct {
+	Hints    []Hint   `json:"hints,omitempty"`
+	Builders []string `json:"builders,omitempty"`
+}
+
+// MarshalJSON implements the [json.Marshaler].
+func (m MevSendBundleRequest) MarshalJSON() ([]byte, error) {
+	var enc mevSendBundleRequest
+
+	enc.Version = m.Version
+	if enc.Version == "" {
+		enc.Version = "v0.1"
+	}
+	enc.Inclusion.BlockNumber = (*hexutil.Big)(m.Inclusion.BlockNumber)
+	enc.Inclusion.MaxBlockNumber = (*hexutil.Big)(m.Inclusion.MaxBlockNumber)
+
+	enc.Body = make([]mevBundleBody, len(m.Body))
+	for i, body := range m.Body {
+		if body.Tx != nil {
+			rawTx, err := body.Tx.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			enc.Body[i].Tx = rawTx
+		} else {
+			enc.Body[i].Tx = body.RawTx
+		}
+		enc.Body[i].Hash = body.Hash
+		enc.Body[i].CanRevert = body.CanRevert
+		enc.Body[i].Bundle = body.Bundle
+	}
+
+	if m.Validity != nil {
+		enc.Validity = new(mevBundleValidity)
+		for _, refund := range m.Validity.Refund {
+			enc.Validity.Refund = append(enc.Validity.Refund, mevBundleRefund(refund))
+		}
+		for _, refundConfig := range m.Validity.RefundConfig {
+			enc.Validity.RefundConfig = append(enc.Validity.RefundConfig, mevBundleRefundConfig(refundConfig))
+		}
+	}
+	if m.Privacy != nil {
+		enc.Privacy = &mevBundlePrivacy{
+			Hints:    m.Privacy.Hints,
+			Builders: m.Privacy.Builders,
+		}
+	}
+	return json.Marshal(&enc)
+}
+
+// MevSendBundle sends the MEV-Share bundle to the client's endpoint.
+func MevSendBundle(r *MevSendBundleRequest) w3types.RPCCallerFactory[common.Hash] {
+	return &mevSendBundleFactory{param: r}
+}
+
+type mevSendBundleFactory struct {
+	// args
+	param *MevSendBundleRequest
+
+	// returns
+	result  sendBundleResponse
+	returns *common.Hash
+}
+
+func (f *mevSendBundleFactory) Returns(hash *common.Hash) w3types.RPCCaller {
+	f.returns = hash
+	return f
+}
+
+// CreateRequest implements the [w3types.RequestCreator].
+func (f *mevSendBundleFactory) CreateRequest() (rpc.BatchElem, error) {
+	return rpc.BatchElem{
+		Method: "mev_sendBundle",
+		Args:   []any{f.param},
+		Result: &f.result,
+	}, nil
+}
+
+// HandleResponse implements the [w3types.ResponseHandler].
+func (f *mevSendBundleFactory) HandleResponse(elem rpc.BatchElem) error {
+	if err := elem.Error; err != nil {
+		return wrapRPCError(err)
+	}
+	if f.returns != nil {
+		*f.returns = f.result.BundleHash
+	}
+	return nil
+}
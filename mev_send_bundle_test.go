@@ -0,0 +1,48 @@
+package flashbots_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/flashbots"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestMevSendBundle(t *testing.T) {
+	backrunTxHash := w3.H("0x45df1bc3de765927b053ec029fc9d15d6321945b23cac0614eb0b5e61f3a2f2a")
+
+	rpctest.RunTestCases(t, []rpctest.TestCase[common.Hash]{
+		{
+			Golden: "mev_send_bundle",
+			Call: flashbots.MevSendBundle(&flashbots.MevSendBundleRequest{
+				Inclusion: flashbots.MevBundleInclusion{
+					BlockNumber:    big.NewInt(9_999_999),
+					MaxBlockNumber: big.NewInt(10_000_009),
+				},
+				Body: []flashbots.MevBundleBody{
+					{Hash: &backrunTxHash},
+					{RawTx: w3.B("0x00"), CanRevert: true},
+					{Bundle: &flashbots.MevSendBundleRequest{
+						Inclusion: flashbots.MevBundleInclusion{BlockNumber: big.NewInt(9_999_999)},
+						Body:      []flashbots.MevBundleBody{{RawTx: w3.B("0x01")}},
+					}},
+				},
+				Validity: &flashbots.MevBundleValidity{
+					Refund: []flashbots.MevBundleRefund{
+						{BodyIdx: 0, Percent: 90},
+					},
+					RefundConfig: []flashbots.MevBundleRefundConfig{
+						{Address: w3.A("0x000000000000000000000000000000000000c0Fe"), Percent: 100},
+					},
+				},
+				Privacy: &flashbots.MevBundlePrivacy{
+					Hints:    []flashbots.Hint{flashbots.HintCalldata, flashbots.HintLogs},
+					Builders: []string{"flashbots"},
+				},
+			}),
+			WantRet: w3.H("0x2228f5d8954ce31dc1601a8ba264dbd401bf1428388ce88238932815c5d6f23f"),
+		},
+	})
+}
@@ -0,0 +1,261 @@
+package flashbots
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// MevShareStreamURL is the URL of the Flashbots MEV-Share event stream.
+const MevShareStreamURL = "https://mev-share.flashbots.net"
+
+// MevShareEvent is a hint of a pending transaction or bundle emitted by the
+// MEV-Share event stream. Which fields are set depends on the hints the sender
+// of the transaction or bundle chose to share.
+//
+// Use the events Hash as [MevBundleBody.Hash] to backrun the transaction or
+// bundle.
+type MevShareEvent struct {
+	Hash        common.Hash      // Hash of the transaction or bundle.
+	Logs        []MevShareLog    // Logs emitted by the transaction or bundle.
+	Txs         []MevShareTxHint // Hints of the transactions.
+	MevGasPrice *big.Int         // Gas price paid to the builder.
+	GasUsed     uint64           // Gas used by the transaction or bundle.
+}
+
+type MevShareLog struct {
+	Address common.Address
+	Topics  []common.Hash
+	Data    []byte
+}
+
+type MevShareTxHint struct {
+	Hash             *common.Hash    // Hash of the transaction.
+	To               *common.Address // Recipient of the transaction.
+	FunctionSelector []byte          // Function selector of the transactions calldata.
+	CallData         []byte          // Calldata of the transaction.
+}
+
+type mevShareEvent struct {
+	Hash        *common.Hash     `json:"hash"`
+	Logs        []mevShareLog    `json:"logs"`
+	Txs         []mevShareTxHint `json:"txs"`
+	MevGasPrice *hexutil.Big     `json:"mevGasPrice"`
+	GasUsed     *hexutil.Uint64  `json:"gasUsed"`
+}
+
+type mevShareLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+type mevShareTxHint struct {
+	Hash             *common.Hash    `json:"hash"`
+	To               *common.Address `json:"to"`
+	FunctionSelector hexutil.Bytes   `json:"functionSelector"`
+	CallData         hexutil.Bytes   `json:"callData"`
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (e *MevShareEvent) UnmarshalJSON(input []byte) error {
+	var dec mevShareEvent
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+
+	if dec.Hash != nil {
+		e.Hash = *dec.Hash
+	}
+	if dec.Logs != nil {
+		e.Logs = make([]MevShareLog, len(dec.Logs))
+		for i, log := range dec.Logs {
+			e.Logs[i] = MevShareLog{
+				Address: log.Address,
+				Topics:  log.Topics,
+				Data:    log.Data,
+			}
+		}
+	}
+	if dec.Txs != nil {
+		e.Txs = make([]MevShareTxHint, len(dec.Txs))
+		for i, tx := range dec.Txs {
+			e.Txs[i] = MevShareTxHint{
+				Hash:             tx.Hash,
+				To:               tx.To,
+				FunctionSelector: tx.FunctionSelector,
+				CallData:         tx.CallData,
+			}
+		}
+	}
+	if dec.MevGasPrice != nil {
+		e.MevGasPrice = (*big.Int)(dec.MevGasPrice)
+	}
+	if dec.GasUsed != nil {
+		e.GasUsed = uint64(*dec.GasUsed)
+	}
+	return nil
+}
+
+// MevShareOption is an option of [SubscribeMevShare].
+type MevShareOption func(*mevShareStream)
+
+// WithMevShareHTTPClient sets the client used to connect to the MEV-Share event
+// stream. The [http.DefaultClient] is used by default.
+func WithMevShareHTTPClient(client *http.Client) MevShareOption {
+	return func(s *mevShareStream) { s.client = client }
+}
+
+// SubscribeMevShare subscribes to the MEV-Share event stream at the given URL
+// (e.g. [MevShareStreamURL]). An error is returned if the initial connection
+// establishment fails.
+//
+// If the connection is lost, SubscribeMevShare reconnects automatically and
+// resumes the stream at the last received event. The returned channel is
+// closed once the context is canceled.
+//
+// An event with empty data is sent as zero [MevShareEvent].
+func SubscribeMevShare(ctx context.Context, url string, opts ...MevShareOption) (<-chan MevShareEvent, error) {
+	stream := &mevShareStream{url: url, retry: time.Second}
+	for _, opt := range opts {
+		opt(stream)
+	}
+	if stream.client == nil {
+		stream.client = http.DefaultClient
+	}
+
+	body, err := stream.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan MevShareEvent)
+	go stream.run(ctx, body, ch)
+	return ch, nil
+}
+
+type mevShareStream struct {
+	url         string
+	client      *http.Client
+	lastEventID string
+	retry       time.Duration
+}
+
+func (s *mevShareStream) connect(ctx context.Context) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if s.lastEventID != "" {
+		req.Header.Set("Last-Event-ID", s.lastEventID)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("flashbots: unexpected status %q", resp.Status)
+	}
+	return resp.Body, nil
+}
+
+func (s *mevShareStream) run(ctx context.Context, body io.ReadCloser, ch chan<- MevShareEvent) {
+	defer close(ch)
+
+	for {
+		s.read(ctx, body, ch)
+		body.Close()
+
+		// reconnect
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(s.retry):
+			}
+
+			var err error
+			if body, err = s.connect(ctx); err == nil {
+				break
+			}
+		}
+	}
+}
+
+// read reads server-sent events from r and sends them to ch until r is
+// exhausted or the context is canceled.
+func (s *mevShareStream) read(ctx context.Context, r io.Reader, ch chan<- MevShareEvent) {
+	scan := bufio.NewScanner(r)
+	scan.Buffer(nil, 1<<20)
+
+	var (
+		data    bytes.Buffer
+		hasData bool // data field was received, even if empty
+	)
+	for scan.Scan() {
+		line := scan.Bytes()
+
+		// dispatch event on empty line
+		if len(line) <= 0 {
+			if !hasData {
+				continue
+			}
+
+			var (
+				event MevShareEvent
+				err   error
+			)
+			if data.Len() > 0 {
+				err = json.Unmarshal(data.Bytes(), &event)
+			}
+			data.Reset()
+			hasData = false
+			if err != nil {
+				continue // skip invalid events
+			}
+
+			select {
+			case ch <- event:
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+
+		// ignore comments
+		if line[0] == ':' {
+			continue
+		}
+
+		field, value, _ := bytes.Cut(line, []byte(":"))
+		value = bytes.TrimPrefix(value, []byte(" "))
+		switch string(field) {
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.Write(value)
+			hasData = true
+		case "id":
+			s.lastEventID = string(value)
+		case "retry":
+			if ms, err := strconv.ParseUint(string(value), 10, 64); err == nil {
+				s.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package flashbots_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/flashbots"
+	"github.com/lmittmann/w3"
+)
+
+func TestSubscribeMevShare(t *testing.T) {
+	var conns atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		switch conns.Add(1) {
+		case 1:
+			fmt.Fprint(w, "retry: 1\n\n")
+			fmt.Fprint(w, ":ping\n\n")
+			fmt.Fprint(w, "id: 1\n")
+			fmt.Fprint(w, `data: {"hash":"0x45df1bc3de765927b053ec029fc9d15d6321945b23cac0614eb0b5e61f3a2f2a","logs":[{"address":"0x000000000000000000000000000000000000c0fe","topics":["0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"],"data":"0x01"}],"txs":null,"mevGasPrice":"0x3b9aca00","gasUsed":"0x5208"}`+"\n\n")
+		case 2:
+			if got := r.Header.Get("Last-Event-ID"); got != "1" {
+				t.Errorf("Last-Event-ID: want %q, got %q", "1", got)
+			}
+			fmt.Fprint(w, "id: 2\n")
+			fmt.Fprint(w, `data: {"hash":"0x2228f5d8954ce31dc1601a8ba264dbd401bf1428388ce88238932815c5d6f23f","logs":null,"txs":[{"to":"0x000000000000000000000000000000000000c0fe","functionSelector":"0xa9059cbb","callData":"0xa9059cbb"}]}`+"\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		default:
+			<-r.Context().Done()
+		}
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := flashbots.SubscribeMevShare(ctx, srv.URL)
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+
+	wantEvents := []flashbots.MevShareEvent{
+		{
+			Hash: w3.H("0x45df1bc3de765927b053ec029fc9d15d6321945b23cac0614eb0b5e61f3a2f2a"),
+			Logs: []flashbots.MevShareLog{
+				{
+					Address: w3.A("0x000000000000000000000000000000000000c0Fe"),
+					Topics:  []common.Hash{w3.H("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")},
+					Data:    w3.B("0x01"),
+				},
+			},
+			MevGasPrice: w3.I("1 gwei"),
+			GasUsed:     21000,
+		},
+		{
+			Hash: w3.H("0x2228f5d8954ce31dc1601a8ba264dbd401bf1428388ce88238932815c5d6f23f"),
+			Txs: []flashbots.MevShareTxHint{
+				{
+					To:               w3.APtr("0x000000000000000000000000000000000000c0Fe"),
+					FunctionSelector: w3.B("0xa9059cbb"),
+					CallData:         w3.B("0xa9059cbb"),
+				},
+			},
+		},
+	}
+	for i, want := range wantEvents {
+		got, ok := <-events
+		if !ok {
+			t.Fatalf("Event %d: channel closed", i)
+		}
+		if diff := cmp.Diff(want, got, cmp.AllowUnexported(big.Int{})); diff != "" {
+			t.Fatalf("Event %d: (-want, +got)\n%s", i, diff)
+		}
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestSubscribeMevShareEmptyData(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data:\n\n")
+		fmt.Fprint(w, `data: {"hash":"0x2228f5d8954ce31dc1601a8ba264dbd401bf1428388ce88238932815c5d6f23f"}`+"\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var requests atomic.Int32
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests.Add(1)
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+	events, err := flashbots.SubscribeMevShare(ctx, srv.URL, flashbots.WithMevShareHTTPClient(client))
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+
+	wantEvents := []flashbots.MevShareEvent{
+		{},
+		{Hash: w3.H("0x2228f5d8954ce31dc1601a8ba264dbd401bf1428388ce88238932815c5d6f23f")},
+	}
+	for i, want := range wantEvents {
+		got, ok := <-events
+		if !ok {
+			t.Fatalf("Event %d: channel closed", i)
+		}
+		if diff := cmp.Diff(want, got, cmp.AllowUnexported(big.Int{})); diff != "" {
+			t.Fatalf("Event %d: (-want, +got)\n%s", i, diff)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("Requests: want 1, got %d", got)
+	}
+
+	cancel()
+	for range events {
+	}
+}
@@ -0,0 +1,172 @@
+package flashbots
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lmittmann/flashbots/internal"
+	"github.com/lmittmann/w3/w3types"
+)
+
+type MevSimBundleOverrides struct {
+	ParentBlock *big.Int        // Block number of state to use for simulation, "latest" if nil.
+	BlockNumber *big.Int        // Block number used for simulation (Optional).
+	Coinbase    *common.Address // Coinbase used for simulation (Optional).
+	Timestamp   uint64          // Timestamp used for simulation (Optional).
+	GasLimit    uint64          // Gas limit used for simulation (Optional).
+	BaseFee     *big.Int        // Base fee used for simulation (Optional).
+	Timeout     time.Duration   // Timeout of the simulation, rounded up to whole seconds (Optional).
+}
+
+type mevSimBundleOverrides struct {
+	ParentBlock string          `json:"parentBlock,omitempty"`
+	BlockNumber *hexutil.Big    `json:"blockNumber,omitempty"`
+	Coinbase    *common.Address `json:"coinbase,omitempty"`
+	Timestamp   hexutil.Uint64  `json:"timestamp,omitempty"`
+	GasLimit    hexutil.Uint64  `json:"gasLimit,omitempty"`
+	BaseFee     *hexutil.Big    `json:"baseFee,omitempty"`
+	Timeout     int64           `json:"timeout,omitempty"`
+}
+
+// MarshalJSON implements the [json.Marshaler].
+func (o MevSimBundleOverrides) MarshalJSON() ([]byte, error) {
+	var enc mevSimBundleOverrides
+
+	if o.ParentBlock != nil {
+		enc.ParentBlock = toBlockNumberArg(o.ParentBlock)
+	}
+	enc.BlockNumber = (*hexutil.Big)(o.BlockNumber)
+	enc.Coinbase = o.Coinbase
+	enc.Timestamp = hexutil.Uint64(o.Timestamp)
+	enc.GasLimit = hexutil.Uint64(o.GasLimit)
+	enc.BaseFee = (*hexutil.Big)(o.BaseFee)
+	enc.Timeout = int64((o.Timeout + time.Second - 1) / time.Second)
+	return json.Marshal(&enc)
+}
+
+type MevSimBundleResponse struct {
+	Success         bool
+	Error           error
+	StateBlock      uint64
+	MevGasPrice     *big.Int
+	Profit          *big.Int
+	RefundableValue *big.Int
+	GasUsed         uint64
+	BodyLogs        []MevSimBundleLogs
+}
+
+// MevSimBundleLogs are the logs of a body element of a simulated bundle. Either
+// TxLogs or BundleLogs is set.
+type MevSimBundleLogs struct {
+	TxLogs     []*types.Log
+	BundleLogs []MevSimBundleLogs
+}
+
+type mevSimBundleResponse struct {
+	Success         *bool              `json:"success"`
+	Error           *string            `json:"error"`
+	StateBlock      *hexutil.Uint64    `json:"stateBlock"`
+	MevGasPrice     *internal.StrInt   `json:"mevGasPrice"`
+	Profit          *internal.StrInt   `json:"profit"`
+	RefundableValue *internal.StrInt   `json:"refundableValue"`
+	GasUsed         *hexutil.Uint64    `json:"gasUsed"`
+	BodyLogs        []MevSimBundleLogs `json:"logs"`
+}
+
+type mevSimBundleLogs struct {
+	TxLogs     []*types.Log       `json:"txLogs"`
+	BundleLogs []MevSimBundleLogs `json:"bundleLogs"`
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (m *MevSimBundleResponse) UnmarshalJSON(input []byte) error {
+	var dec mevSimBundleResponse
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+
+	if dec.Success != nil {
+		m.Success = *dec.Success
+	}
+	if dec.Error != nil && *dec.Error != "" {
+		m.Error = errors.New(*dec.Error)
+	}
+	if dec.StateBlock != nil {
+		m.StateBlock = uint64(*dec.StateBlock)
+	}
+	if dec.MevGasPrice != nil {
+		m.MevGasPrice = (*big.Int)(dec.MevGasPrice)
+	}
+	if dec.Profit != nil {
+		m.Profit = (*big.Int)(dec.Profit)
+	}
+	if dec.RefundableValue != nil {
+		m.RefundableValue = (*big.Int)(dec.RefundableValue)
+	}
+	if dec.GasUsed != nil {
+		m.GasUsed = uint64(*dec.GasUsed)
+	}
+	if dec.BodyLogs != nil {
+		m.BodyLogs = dec.BodyLogs
+	}
+	return nil
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (m *MevSimBundleLogs) UnmarshalJSON(input []byte) error {
+	var dec mevSimBundleLogs
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+
+	m.TxLogs = dec.TxLogs
+	m.BundleLogs = dec.BundleLogs
+	return nil
+}
+
+// MevSimBundle simulates a MEV-Share bundle. The simulation environment can be
+// adjusted with the optional overrides.
+func MevSimBundle(r *MevSendBundleRequest, overrides *MevSimBundleOverrides) w3types.RPCCallerFactory[*MevSimBundleResponse] {
+	return &mevSimBundleFactory{param: r, overrides: overrides}
+}
+
+type mevSimBundleFactory struct {
+	// args
+	param     *MevSendBundleRequest
+	overrides *MevSimBundleOverrides
+
+	// returns
+	returns **MevSimBundleResponse
+}
+
+func (f *mevSimBundleFactory) Returns(resp **MevSimBundleResponse) w3types.RPCCaller {
+	f.returns = resp
+	return f
+}
+
+// CreateRequest implements the [w3types.RequestCreator].
+func (f *mevSimBundleFactory) CreateRequest() (rpc.BatchElem, error) {
+	overrides := f.overrides
+	if overrides == nil {
+		overrides = new(MevSimBundleOverrides)
+	}
+	return rpc.BatchElem{
+		Method: "mev_simBundle",
+		Args:   []any{f.param, overrides},
+		Result: f.returns,
+	}, nil
+}
+
+// HandleResponse implements the [w3types.ResponseHandler].
+func (f *mevSimBundleFactory) HandleResponse(elem rpc.BatchElem) error {
+	if err := elem.Error; err != nil {
+		return wrapRPCError(err)
+	}
+	return nil
+}
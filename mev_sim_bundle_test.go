@@ -0,0 +1,101 @@
+package flashbots_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/flashbots"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestMevSimBundle(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*flashbots.MevSimBundleResponse]{
+		{
+			Golden: "mev_sim_bundle",
+			Call: flashbots.MevSimBundle(
+				&flashbots.MevSendBundleRequest{
+					Inclusion: flashbots.MevBundleInclusion{BlockNumber: big.NewInt(9_999_999)},
+					Body: []flashbots.MevBundleBody{
+						{RawTx: w3.B("0x00")},
+						{Bundle: &flashbots.MevSendBundleRequest{
+							Inclusion: flashbots.MevBundleInclusion{BlockNumber: big.NewInt(9_999_999)},
+							Body:      []flashbots.MevBundleBody{{RawTx: w3.B("0x01")}},
+						}},
+					},
+				},
+				&flashbots.MevSimBundleOverrides{
+					ParentBlock: big.NewInt(9_999_998),
+					Coinbase:    w3.APtr("0x000000000000000000000000000000000000c0Fe"),
+					BaseFee:     w3.I("10 gwei"),
+					Timeout:     5 * time.Second,
+				},
+			),
+			WantRet: &flashbots.MevSimBundleResponse{
+				Success:         true,
+				StateBlock:      9_999_998,
+				MevGasPrice:     w3.I("476190476193"),
+				Profit:          w3.I("20000000000126000"),
+				RefundableValue: w3.I("20000000000000000"),
+				GasUsed:         42000,
+				BodyLogs: []flashbots.MevSimBundleLogs{
+					{TxLogs: []*types.Log{}},
+					{BundleLogs: []flashbots.MevSimBundleLogs{
+						{TxLogs: []*types.Log{
+							{
+								Address: w3.A("0x000000000000000000000000000000000000c0Fe"),
+								Topics:  []common.Hash{w3.H("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")},
+								Data:    w3.B("0x"),
+								TxHash:  w3.H("0xa839ee83465657cac01adc1d50d96c1b586ed498120a84a64749c0034b4f19fa"),
+							},
+						}},
+					}},
+				},
+			},
+		},
+		{
+			Golden: "mev_sim_bundle_failed",
+			Call: flashbots.MevSimBundle(
+				&flashbots.MevSendBundleRequest{
+					Inclusion: flashbots.MevBundleInclusion{BlockNumber: big.NewInt(9_999_999)},
+					Body:      []flashbots.MevBundleBody{{RawTx: w3.B("0x00")}},
+				},
+				nil,
+			),
+			WantRet: &flashbots.MevSimBundleResponse{
+				Success:         false,
+				Error:           errors.New("execution reverted"),
+				StateBlock:      9_999_998,
+				MevGasPrice:     big.NewInt(0),
+				Profit:          big.NewInt(0),
+				RefundableValue: big.NewInt(0),
+				GasUsed:         21000,
+			},
+		},
+		{
+			Golden: "mev_sim_bundle_timeout",
+			Call: flashbots.MevSimBundle(
+				&flashbots.MevSendBundleRequest{
+					Inclusion: flashbots.MevBundleInclusion{BlockNumber: big.NewInt(9_999_999)},
+					Body:      []flashbots.MevBundleBody{{RawTx: w3.B("0x00")}},
+				},
+				&flashbots.MevSimBundleOverrides{Timeout: 500 * time.Millisecond},
+			),
+			WantRet: &flashbots.MevSimBundleResponse{
+				Success:         true,
+				StateBlock:      9_999_998,
+				MevGasPrice:     w3.I("476190476193"),
+				Profit:          w3.I("20000000000000048"),
+				RefundableValue: w3.I("20000000000000000"),
+				GasUsed:         42000,
+			},
+		},
+	}, cmp.Comparer(func(x, y error) bool {
+		return x == nil && y == nil || x != nil && y != nil && x.Error() == y.Error()
+	}))
+}
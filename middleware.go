@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
@@ -22,17 +25,22 @@ func AuthTransport(privKey *ecdsa.PrivateKey) http.RoundTripper {
 	if privKey == nil {
 		return &authRoundTripper{}
 	}
-	return &authRoundTripper{privKey, crypto.PubkeyToAddress(privKey.PublicKey), http.DefaultTransport}
+	return AuthTransportWithSigner(NewPrivateKeySigner(privKey))
+}
+
+// AuthTransportWithSigner is like [AuthTransport] but signs every request with
+// the given [Signer].
+func AuthTransportWithSigner(signer Signer) http.RoundTripper {
+	return &authRoundTripper{signer, http.DefaultTransport}
 }
 
 type authRoundTripper struct {
-	privKey *ecdsa.PrivateKey
-	addr    common.Address
-	next    http.RoundTripper
+	signer Signer
+	next   http.RoundTripper
 }
 
 func (auth *authRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
-	if auth.privKey == nil {
+	if auth.signer == nil {
 		return nil, errors.New("flashbots: key is nil")
 	}
 
@@ -56,12 +64,105 @@ func (auth *authRoundTripper) RoundTrip(r *http.Request) (*http.Response, error)
 }
 
 func (auth *authRoundTripper) sign(body []byte) (string, error) {
-	bodyHash := crypto.Keccak256(body)
-	sig, err := crypto.Sign(accounts.TextHash([]byte(hexutil.Encode(bodyHash))), auth.privKey)
+	sig, err := auth.signer.SignHash(crypto.Keccak256Hash(body))
 	if err != nil {
 		return "", err
 	}
-	return auth.addr.Hex() + ":" + hexutil.Encode(sig), nil
+	if len(sig) != crypto.SignatureLength {
+		return "", fmt.Errorf("flashbots: invalid signature length %d", len(sig))
+	}
+
+	// transform V from Ethereum-legacy (27/28) to 0/1
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig = bytes.Clone(sig)
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	return auth.signer.Address().Hex() + ":" + hexutil.Encode(sig), nil
+}
+
+// ErrInvalidSignature is returned if the 'X-Flashbots-Signature' header of a
+// request is missing or invalid.
+var ErrInvalidSignature = errors.New("flashbots: invalid signature")
+
+// VerifySignature verifies the 'X-Flashbots-Signature' header of a request
+// with the given body and returns the address of the signer. An error that
+// wraps [ErrInvalidSignature] is returned if the header is missing, malformed,
+// or the signature was not created by the address in the header.
+func VerifySignature(header string, body []byte) (common.Address, error) {
+	if header == "" {
+		return common.Address{}, fmt.Errorf("%w: missing header", ErrInvalidSignature)
+	}
+
+	rawAddr, rawSig, ok := strings.Cut(header, ":")
+	if !ok || !common.IsHexAddress(rawAddr) {
+		return common.Address{}, fmt.Errorf("%w: malformed header", ErrInvalidSignature)
+	}
+	sig, err := hexutil.Decode(rawSig)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("%w: malformed header", ErrInvalidSignature)
+	}
+
+	// transform V from Ethereum-legacy (27/28) to 0/1, as the relay does
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	addr := common.HexToAddress(rawAddr)
+	bodyHash := crypto.Keccak256(body)
+	pubKey, err := crypto.SigToPub(accounts.TextHash([]byte(hexutil.Encode(bodyHash))), sig)
+	if err != nil || crypto.PubkeyToAddress(*pubKey) != addr {
+		return common.Address{}, fmt.Errorf("%w: signer mismatch", ErrInvalidSignature)
+	}
+	return addr, nil
+}
+
+type signerContextKey struct{}
+
+// maxRequestSize is the max size of a request body accepted by the
+// [AuthHandler].
+const maxRequestSize = 10 << 20 // 10 MiB
+
+// AuthHandler returns a http.Handler that verifies the 'X-Flashbots-Signature'
+// header of every request before passing it to the next handler. The address
+// of the signer is added to the requests context and can be retrieved with
+// [SignerFromContext]. Requests with a missing or invalid signature are
+// rejected with status 403, requests with a body larger than 10 MiB with
+// status 413.
+func AuthHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+		if err != nil {
+			status := http.StatusBadRequest
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		addr, err := VerifySignature(r.Header.Get("X-Flashbots-Signature"), body)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]any{
+				"jsonrpc": "2.0",
+				"id":      nil,
+				"error":   map[string]any{"code": -32600, "message": err.Error()},
+			})
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), signerContextKey{}, addr)))
+	})
+}
+
+// SignerFromContext returns the address of the signer of a request that was
+// verified by the [AuthHandler].
+func SignerFromContext(ctx context.Context) (addr common.Address, ok bool) {
+	addr, ok = ctx.Value(signerContextKey{}).(common.Address)
+	return
 }
 
 // Dial returns a new [w3.Client] connected to the URL rawurl that adds the
@@ -70,12 +171,31 @@ func (auth *authRoundTripper) sign(body []byte) (string, error) {
 //
 // Use [w3.Dial] to connect to an RPC endpoint that does not require signed
 // requests.
-func Dial(rawurl string, prv *ecdsa.PrivateKey) (*w3.Client, error) {
+func Dial(rawurl string, prv *ecdsa.PrivateKey, opts ...DialOption) (*w3.Client, error) {
+	return dial(rawurl, AuthTransport(prv), opts)
+}
+
+func dial(rawurl string, transport http.RoundTripper, opts []DialOption) (*w3.Client, error) {
+	var o dialOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.batchSplitting {
+		transport = &batchSplitRoundTripper{next: transport}
+	}
+	transport = &httpErrorRoundTripper{next: transport}
+	if o.protectConfig != nil {
+		var err error
+		if rawurl, err = o.protectConfig.URL(rawurl); err != nil {
+			return nil, err
+		}
+	}
+
 	rpcClient, err := rpc.DialOptions(
 		context.Background(),
 		rawurl,
 		rpc.WithHTTPClient(&http.Client{
-			Transport: AuthTransport(prv),
+			Transport: transport,
 		}),
 	)
 	if err != nil {
@@ -84,12 +204,18 @@ func Dial(rawurl string, prv *ecdsa.PrivateKey) (*w3.Client, error) {
 	return w3.NewClient(rpcClient), nil
 }
 
+// DialWithSigner is like [Dial] but signs every request with the given
+// [Signer].
+func DialWithSigner(rawurl string, signer Signer, opts ...DialOption) (*w3.Client, error) {
+	return dial(rawurl, AuthTransportWithSigner(signer), opts)
+}
+
 // MustDial is like [Dial] but panics if the connection establishment fails.
 //
 // Use [w3.MustDial] to connect to an RPC endpoint that does not require signed
 // requests.
-func MustDial(rawurl string, prv *ecdsa.PrivateKey) *w3.Client {
-	client, err := Dial(rawurl, prv)
+func MustDial(rawurl string, prv *ecdsa.PrivateKey, opts ...DialOption) *w3.Client {
+	client, err := Dial(rawurl, prv, opts...)
 	if err != nil {
 		panic("flashbots: " + err.Error())
 	}
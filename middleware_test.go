@@ -1,8 +1,16 @@
 package flashbots
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -14,10 +22,7 @@ func TestSign(t *testing.T) {
 		t.Fatalf("Failed to read key: %v", err)
 	}
 
-	authRT := &authRoundTripper{
-		privKey: privKey,
-		addr:    crypto.PubkeyToAddress(privKey.PublicKey),
-	}
+	authRT := &authRoundTripper{signer: NewPrivateKeySigner(privKey)}
 	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_sendBundle","params":[{"txs":["0x00","0x01"],"blockNumber":"0x98967f"}]}`)
 	gotSig, err := authRT.sign(body)
 	if err != nil {
@@ -36,10 +41,7 @@ func BenchmarkSign(b *testing.B) {
 		b.Fatalf("Failed to read key: %v", err)
 	}
 
-	authRT := &authRoundTripper{
-		privKey: privKey,
-		addr:    crypto.PubkeyToAddress(privKey.PublicKey),
-	}
+	authRT := &authRoundTripper{signer: NewPrivateKeySigner(privKey)}
 	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_sendBundle","params":[{"txs":["0x00","0x01"],"blockNumber":"0x98967f"}]}`)
 
 	for i := 0; i < b.N; i++ {
@@ -48,3 +50,119 @@ func BenchmarkSign(b *testing.B) {
 		}
 	}
 }
+
+func TestVerifySignature(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_sendBundle","params":[{"txs":["0x00","0x01"],"blockNumber":"0x98967f"}]}`)
+	sig := "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf:0x2765bcbc32f0c6fc822e1d34e188f8337ec52524a7fd4346ba3ca785f3c641a51aaabe9b9392657ab0fd635fb0b527b2dacca7fea1b6b1c3eae553ded693073e01"
+
+	tests := []struct {
+		Header   string
+		Body     []byte
+		WantAddr common.Address
+		WantErr  string
+	}{
+		{
+			Header:   sig,
+			Body:     body,
+			WantAddr: common.HexToAddress("0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"),
+		},
+		{
+			Header:   sig[:len(sig)-2] + "1c", // V=28
+			Body:     body,
+			WantAddr: common.HexToAddress("0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"),
+		},
+		{
+			Header:  "",
+			Body:    body,
+			WantErr: "flashbots: invalid signature: missing header",
+		},
+		{
+			Header:  "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf",
+			Body:    body,
+			WantErr: "flashbots: invalid signature: malformed header",
+		},
+		{
+			Header:  "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf:0x00",
+			Body:    body,
+			WantErr: "flashbots: invalid signature: malformed header",
+		},
+		{
+			Header:  sig,
+			Body:    []byte(`{}`),
+			WantErr: "flashbots: invalid signature: signer mismatch",
+		},
+		{
+			Header:  "0x000000000000000000000000000000000000c0Fe" + sig[42:],
+			Body:    body,
+			WantErr: "flashbots: invalid signature: signer mismatch",
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			gotAddr, err := VerifySignature(test.Header, test.Body)
+			if test.WantErr != "" {
+				if err == nil || err.Error() != test.WantErr || !errors.Is(err, ErrInvalidSignature) {
+					t.Fatalf("Err: want %q, got %v", test.WantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if test.WantAddr != gotAddr {
+				t.Fatalf("want %s\ngot  %s", test.WantAddr, gotAddr)
+			}
+		})
+	}
+}
+
+func TestAuthHandler(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+	if err != nil {
+		t.Fatalf("Failed to read key: %v", err)
+	}
+
+	srv := httptest.NewServer(AuthHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addr, _ := SignerFromContext(r.Context())
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "%s %s", addr, body)
+	})))
+	defer srv.Close()
+
+	// signed request
+	client := &http.Client{Transport: AuthTransport(privKey)}
+	resp, err := client.Post(srv.URL, "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	gotBody, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if want := "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf {}"; resp.StatusCode != http.StatusOK || string(gotBody) != want {
+		t.Fatalf("want %d %q\ngot  %d %q", http.StatusOK, want, resp.StatusCode, gotBody)
+	}
+
+	// unsigned request
+	resp, err = http.Post(srv.URL, "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("want %d\ngot  %d", http.StatusForbidden, resp.StatusCode)
+	}
+
+	// request with too large body
+	resp, err = http.Post(srv.URL, "application/json", strings.NewReader(strings.Repeat(" ", maxRequestSize+1)))
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Fatalf("want %d\ngot  %d", http.StatusRequestEntityTooLarge, resp.StatusCode)
+	}
+}
@@ -2,7 +2,9 @@ package flashbots
 
 import (
 	"encoding/json"
+	"errors"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -16,32 +18,105 @@ type SendPrivateTxRequest struct {
 	RawTx          []byte             // Raw signed transaction to send.
 	MaxBlockNumber *big.Int           // Max block number for which the tx should be included (Optional).
 	Fast           bool               // Enable fast mode (Optional). See https://docs.flashbots.net/flashbots-protect/rpc/fast-mode
+	Hints          []Hint             // Data shared with searchers via MEV-Share (Optional).
+	Builders       []string           // Builders that may receive the tx (Optional).
+	Refunds        []ProtectRefund    // Recipients of the MEV-Share refunds (Optional).
+	AuctionTimeout time.Duration      // Timeout of the MEV-Share auction, encoded in milliseconds (Optional).
 }
 
 type sendPrivateTxRequest struct {
-	RawTx          hexutil.Bytes `json:"tx"`
-	MaxBlockNumber *hexutil.Big  `json:"maxBlockNumber"`
-	Preferences    struct {
-		Fast bool `json:"fast"`
-	} `json:"preferences"`
+	RawTx          hexutil.Bytes        `json:"tx"`
+	MaxBlockNumber *hexutil.Big         `json:"maxBlockNumber"`
+	Preferences    privateTxPreferences `json:"preferences"`
+}
+
+type privateTxPreferences struct {
+	Fast           bool               `json:"fast"`
+	Privacy        *privateTxPrivacy  `json:"privacy,omitempty"`
+	Validity       *privateTxValidity `json:"validity,omitempty"`
+	AuctionTimeout int64              `json:"auctionTimeout,omitempty"`
+}
+
+type privateTxPrivacy struct {
+	Hints    []Hint   `json:"hints,omitempty"`
+	Builders []string `json:"builders,omitempty"`
+}
+
+type privateTxValidity struct {
+	Refund []privateTxRefund `json:"refund,omitempty"`
+}
+
+type privateTxRefund struct {
+	Address common.Address `json:"address"`
+	Percent int            `json:"percent"`
 }
 
 // MarshalJSON implements the [json.Marshaler].
 func (c SendPrivateTxRequest) MarshalJSON() ([]byte, error) {
-	var enc sendPrivateTxRequest
+	var (
+		enc sendPrivateTxRequest
+		err error
+	)
+	if enc.RawTx, err = c.rawTx(); err != nil {
+		return nil, err
+	}
+	enc.MaxBlockNumber = (*hexutil.Big)(c.MaxBlockNumber)
+	enc.Preferences = c.preferences()
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler]. The transaction is decoded
+// into RawTx.
+func (c *SendPrivateTxRequest) UnmarshalJSON(input []byte) error {
+	var dec sendPrivateTxRequest
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
 
+	c.RawTx = dec.RawTx
+	c.MaxBlockNumber = (*big.Int)(dec.MaxBlockNumber)
+	c.setPreferences(&dec.Preferences)
+	return nil
+}
+
+// rawTx returns the binary encoding of Tx, or RawTx if Tx is not set.
+func (c *SendPrivateTxRequest) rawTx() ([]byte, error) {
 	if c.Tx != nil {
-		rawTx, err := c.Tx.MarshalBinary()
-		if err != nil {
-			return nil, err
+		return c.Tx.MarshalBinary()
+	}
+	return c.RawTx, nil
+}
+
+func (c *SendPrivateTxRequest) preferences() privateTxPreferences {
+	prefs := privateTxPreferences{
+		Fast:           c.Fast,
+		AuctionTimeout: c.AuctionTimeout.Milliseconds(),
+	}
+	if len(c.Hints) > 0 || len(c.Builders) > 0 {
+		prefs.Privacy = &privateTxPrivacy{Hints: c.Hints, Builders: c.Builders}
+	}
+	if len(c.Refunds) > 0 {
+		refunds := make([]privateTxRefund, len(c.Refunds))
+		for i, refund := range c.Refunds {
+			refunds[i] = privateTxRefund(refund)
 		}
-		enc.RawTx = rawTx
-	} else {
-		enc.RawTx = c.RawTx
+		prefs.Validity = &privateTxValidity{Refund: refunds}
 	}
-	enc.MaxBlockNumber = (*hexutil.Big)(c.MaxBlockNumber)
-	enc.Preferences.Fast = c.Fast
-	return json.Marshal(&enc)
+	return prefs
+}
+
+func (c *SendPrivateTxRequest) setPreferences(prefs *privateTxPreferences) {
+	c.Fast = prefs.Fast
+	if privacy := prefs.Privacy; privacy != nil {
+		c.Hints = privacy.Hints
+		c.Builders = privacy.Builders
+	}
+	if validity := prefs.Validity; validity != nil {
+		for _, refund := range validity.Refund {
+			c.Refunds = append(c.Refunds, ProtectRefund(refund))
+		}
+	}
+	c.AuctionTimeout = time.Duration(prefs.AuctionTimeout) * time.Millisecond
 }
 
 // SendPrivateTx sends a private transaction to the Flashbots relay.
@@ -72,11 +147,61 @@ func (f *sendPrivateTxFactory) CreateRequest() (rpc.BatchElem, error) {
 
 func (f *sendPrivateTxFactory) HandleResponse(elem rpc.BatchElem) error {
 	if err := elem.Error; err != nil {
-		return err
+		return wrapRPCError(err)
 	}
 	return nil
 }
 
+func (f *sendPrivateTxFactory) validate() error { return f.params.Validate() }
+
+// SendPrivateRawTx sends a private transaction via the
+// eth_sendPrivateRawTransaction method, which takes the raw transaction and
+// its preferences as positional params. The method is supported by the
+// Flashbots relay and many other builders.
+//
+// The MaxBlockNumber of the request is not supported by the method and must
+// not be set.
+func SendPrivateRawTx(r *SendPrivateTxRequest) w3types.RPCCallerFactory[common.Hash] {
+	return &sendPrivateRawTxFactory{params: r}
+}
+
+type sendPrivateRawTxFactory struct {
+	// args
+	params *SendPrivateTxRequest
+
+	// returns
+	returns *common.Hash
+}
+
+func (f *sendPrivateRawTxFactory) Returns(txHash *common.Hash) w3types.RPCCaller {
+	f.returns = txHash
+	return f
+}
+
+func (f *sendPrivateRawTxFactory) CreateRequest() (rpc.BatchElem, error) {
+	if f.params.MaxBlockNumber != nil {
+		return rpc.BatchElem{}, errors.New("flashbots: MaxBlockNumber is not supported by eth_sendPrivateRawTransaction")
+	}
+	rawTx, err := f.params.rawTx()
+	if err != nil {
+		return rpc.BatchElem{}, err
+	}
+	return rpc.BatchElem{
+		Method: "eth_sendPrivateRawTransaction",
+		Args:   []any{hexutil.Bytes(rawTx), f.params.preferences()},
+		Result: f.returns,
+	}, nil
+}
+
+func (f *sendPrivateRawTxFactory) HandleResponse(elem rpc.BatchElem) error {
+	if err := elem.Error; err != nil {
+		return wrapRPCError(err)
+	}
+	return nil
+}
+
+func (f *sendPrivateRawTxFactory) validate() error { return f.params.Validate() }
+
 type cancelPrivateTxRequest struct {
 	TxHash common.Hash `json:"txHash"`
 }
@@ -112,7 +237,7 @@ func (f *cancelPrivateTxFactory) CreateRequest() (rpc.BatchElem, error) {
 
 func (f *cancelPrivateTxFactory) HandleResponse(elem rpc.BatchElem) error {
 	if err := elem.Error; err != nil {
-		return err
+		return wrapRPCError(err)
 	}
 	return nil
 }
@@ -3,6 +3,7 @@ package flashbots_test
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/lmittmann/flashbots"
@@ -21,6 +22,52 @@ func TestSendPrivateTx(t *testing.T) {
 			}),
 			WantRet: w3.H("0x45df1bc3de765927b053ec029fc9d15d6321945b23cac0614eb0b5e61f3a2f2a"),
 		},
+		{
+			Golden: "send_private_transaction_preferences",
+			Call: flashbots.SendPrivateTx(&flashbots.SendPrivateTxRequest{
+				RawTx:          w3.B("0x00"),
+				MaxBlockNumber: big.NewInt(9_999_999),
+				Hints: []flashbots.Hint{
+					flashbots.HintCalldata,
+					flashbots.HintContractAddress,
+					flashbots.HintLogs,
+					flashbots.HintFunctionSelector,
+					flashbots.HintHash,
+				},
+				Builders: []string{"flashbots", "beaverbuild.org"},
+				Refunds: []flashbots.ProtectRefund{
+					{Address: w3.A("0x000000000000000000000000000000000000c0Fe"), Percent: 90},
+				},
+				AuctionTimeout: 1500 * time.Millisecond,
+			}),
+			WantRet: w3.H("0x45df1bc3de765927b053ec029fc9d15d6321945b23cac0614eb0b5e61f3a2f2a"),
+		},
+	})
+}
+
+func TestSendPrivateRawTx(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[common.Hash]{
+		{
+			Golden: "send_private_raw_transaction",
+			Call: flashbots.SendPrivateRawTx(&flashbots.SendPrivateTxRequest{
+				RawTx: w3.B("0x00"),
+				Fast:  true,
+			}),
+			WantRet: w3.H("0x45df1bc3de765927b053ec029fc9d15d6321945b23cac0614eb0b5e61f3a2f2a"),
+		},
+		{
+			Golden: "send_private_raw_transaction_preferences",
+			Call: flashbots.SendPrivateRawTx(&flashbots.SendPrivateTxRequest{
+				RawTx:    w3.B("0x00"),
+				Hints:    []flashbots.Hint{flashbots.HintCalldata, flashbots.HintHash},
+				Builders: []string{"flashbots"},
+				Refunds: []flashbots.ProtectRefund{
+					{Address: w3.A("0x000000000000000000000000000000000000c0Fe"), Percent: 90},
+				},
+				AuctionTimeout: 1500 * time.Millisecond,
+			}),
+			WantRet: w3.H("0x45df1bc3de765927b053ec029fc9d15d6321945b23cac0614eb0b5e61f3a2f2a"),
+		},
 	})
 }
 
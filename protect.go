@@ -0,0 +1,142 @@
+package flashbots
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+)
+
+// ProtectURL is the URL of the Flashbots Protect RPC.
+const ProtectURL = "https://rpc.flashbots.net"
+
+// ProtectConfig is the configuration of the Flashbots Protect RPC, that is set
+// via the query parameters of the Protect RPC URL.
+type ProtectConfig struct {
+	Hints      []Hint          // Data shared with searchers via MEV-Share (Optional).
+	Builders   []string        // Builders that may receive the transactions (Optional).
+	Refunds    []ProtectRefund // Recipients of the MEV-Share refunds (Optional).
+	OriginID   string          // ID of the origin of the transactions, e.g. a wallet (Optional).
+	Fast       bool            // Enable fast mode (Optional).
+	UseMempool bool            // Send transactions to the public mempool if not included (Optional).
+}
+
+// ProtectRefund is a recipient of MEV-Share refunds.
+type ProtectRefund struct {
+	Address common.Address // Recipient of the refund.
+	Percent int            // Share of the refund in percent.
+}
+
+var protectParams = []string{"hint", "builder", "refund", "originId", "fast", "useMempool"}
+
+// URL returns the URL rawurl with the query parameters of the config. The
+// [ProtectURL] is used if rawurl is empty. Query parameters of rawurl that are
+// set by the config are replaced, as is the path "/fast", which enables fast
+// mode regardless of the config.
+func (c *ProtectConfig) URL(rawurl string) (string, error) {
+	if rawurl == "" {
+		rawurl = ProtectURL
+	}
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.TrimSuffix(u.Path, "/") == "/fast" {
+		u.Path = ""
+	}
+
+	q := u.Query()
+	for _, key := range protectParams {
+		q.Del(key)
+	}
+	for _, hint := range c.Hints {
+		q.Add("hint", string(hint))
+	}
+	for _, builder := range c.Builders {
+		q.Add("builder", builder)
+	}
+	for _, refund := range c.Refunds {
+		q.Add("refund", refund.Address.Hex()+":"+strconv.Itoa(refund.Percent))
+	}
+	if c.OriginID != "" {
+		q.Set("originId", c.OriginID)
+	}
+	if c.Fast {
+		q.Set("fast", "true")
+	}
+	if c.UseMempool {
+		q.Set("useMempool", "true")
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// WithProtectConfig sets the query parameters of the Flashbots Protect RPC URL
+// to the given config (see [ProtectConfig.URL]).
+//
+// Use [DialProtect] to connect to the Protect RPC without a key.
+func WithProtectConfig(config *ProtectConfig) DialOption {
+	return func(opts *dialOptions) { opts.protectConfig = config }
+}
+
+// DialProtect returns a new [w3.Client] connected to the Flashbots Protect RPC
+// URL rawurl (e.g. [ProtectURL]) that does not sign requests, as the Protect
+// RPC does not require signed requests for sending transactions. An error is
+// returned if the connection establishment fails.
+//
+// Use [WithProtectConfig] to set the config of the Protect RPC.
+func DialProtect(rawurl string, opts ...DialOption) (*w3.Client, error) {
+	return dial(rawurl, http.DefaultTransport, opts)
+}
+
+// ParseProtectURL parses the config from the query parameters of the given
+// Protect RPC URL. Fast mode is also enabled by the path "/fast".
+func ParseProtectURL(rawurl string) (*ProtectConfig, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+
+	q := u.Query()
+	c := new(ProtectConfig)
+	for _, hint := range q["hint"] {
+		c.Hints = append(c.Hints, Hint(hint))
+	}
+	c.Builders = q["builder"]
+	for _, refund := range q["refund"] {
+		rawAddr, rawPercent, ok := strings.Cut(refund, ":")
+		if !ok || !common.IsHexAddress(rawAddr) {
+			return nil, fmt.Errorf("flashbots: invalid refund %q", refund)
+		}
+		percent, err := strconv.Atoi(rawPercent)
+		if err != nil {
+			return nil, fmt.Errorf("flashbots: invalid refund %q", refund)
+		}
+		c.Refunds = append(c.Refunds, ProtectRefund{Address: common.HexToAddress(rawAddr), Percent: percent})
+	}
+	c.OriginID = q.Get("originId")
+	if c.Fast, err = parseBoolParam(q, "fast"); err != nil {
+		return nil, err
+	}
+	c.Fast = c.Fast || strings.TrimSuffix(u.Path, "/") == "/fast"
+	if c.UseMempool, err = parseBoolParam(q, "useMempool"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func parseBoolParam(q url.Values, key string) (bool, error) {
+	if !q.Has(key) {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(q.Get(key))
+	if err != nil {
+		return false, fmt.Errorf("flashbots: invalid %s %q", key, q.Get(key))
+	}
+	return b, nil
+}
@@ -0,0 +1,141 @@
+package flashbots
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ProtectStatusURL is the URL of the Flashbots Protect transaction status API.
+const ProtectStatusURL = "https://protect.flashbots.net/tx"
+
+// TxStatus is the status of a transaction sent via Flashbots Protect.
+type TxStatus string
+
+const (
+	TxStatusPending   TxStatus = "PENDING"   // Transaction was received and is not yet included.
+	TxStatusIncluded  TxStatus = "INCLUDED"  // Transaction was included on chain.
+	TxStatusFailed    TxStatus = "FAILED"    // Transaction was not included before its max block.
+	TxStatusCancelled TxStatus = "CANCELLED" // Transaction was cancelled by the sender.
+	TxStatusUnknown   TxStatus = "UNKNOWN"   // Transaction is not known (yet).
+)
+
+// IsTerminal returns true if the status is final, i.e. the transaction was
+// included, failed or was cancelled.
+func (s TxStatus) IsTerminal() bool {
+	return s == TxStatusIncluded || s == TxStatusFailed || s == TxStatusCancelled
+}
+
+// PrivateTxStatus is the status of a transaction sent via Flashbots Protect.
+type PrivateTxStatus struct {
+	Status         TxStatus
+	Hash           common.Hash
+	MaxBlockNumber uint64 // Last block in which the transaction may be included.
+	FastMode       bool   // Transaction was sent in fast mode.
+	SeenInMempool  bool   // Transaction was seen in the public mempool.
+	SimError       string // Error of the last simulation, if the transaction was simulated but not included.
+	IsRevert       bool   // Last simulation of the transaction reverted.
+}
+
+type privateTxStatus struct {
+	Status         TxStatus    `json:"status"`
+	Hash           common.Hash `json:"hash"`
+	MaxBlockNumber uint64      `json:"maxBlockNumber"`
+	FastMode       bool        `json:"fastMode"`
+	SeenInMempool  bool        `json:"seenInMempool"`
+	SimError       string      `json:"simError"`
+	IsRevert       bool        `json:"isRevert"`
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (s *PrivateTxStatus) UnmarshalJSON(input []byte) error {
+	var dec privateTxStatus
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*s = PrivateTxStatus(dec)
+	return nil
+}
+
+// ProtectClient is a client of the Flashbots Protect transaction status API.
+type ProtectClient struct {
+	// PollInterval is the interval in which [ProtectClient.WaitPrivateTx] polls
+	// the transaction status. One second is used if zero.
+	PollInterval time.Duration
+
+	// HTTPClient is the client used to send requests. The [http.DefaultClient]
+	// is used if nil.
+	HTTPClient *http.Client
+
+	url string
+}
+
+// NewProtectClient returns a new client of the Flashbots Protect transaction
+// status API at the given URL. The [ProtectStatusURL] is used if url is empty.
+func NewProtectClient(url string) *ProtectClient {
+	if url == "" {
+		url = ProtectStatusURL
+	}
+	return &ProtectClient{url: strings.TrimSuffix(url, "/")}
+}
+
+// TxStatus returns the status of the transaction with the given hash.
+func (c *ProtectClient) TxStatus(ctx context.Context, hash common.Hash) (*PrivateTxStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+"/"+hash.Hex(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("flashbots: unexpected status %q", resp.Status)
+	}
+
+	status := new(PrivateTxStatus)
+	if err := json.NewDecoder(resp.Body).Decode(status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// WaitPrivateTx polls the status of the transaction with the given hash until
+// it is terminal (see [TxStatus.IsTerminal]) and returns the terminal status.
+// An error is returned if the context is canceled or a poll fails.
+func (c *ProtectClient) WaitPrivateTx(ctx context.Context, hash common.Hash) (*PrivateTxStatus, error) {
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		status, err := c.TxStatus(ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+		if status.Status.IsTerminal() {
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
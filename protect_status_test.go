@@ -0,0 +1,110 @@
+package flashbots_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/flashbots"
+	"github.com/lmittmann/w3"
+)
+
+var txHash = w3.H("0x669b4704a7d993a946cdd6e2f95233f308ce0c4649d2e04944e8299efcaa098a")
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestProtectClientTxStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tx/"+txHash.Hex() {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"FAILED","hash":"0x669b4704a7d993a946cdd6e2f95233f308ce0c4649d2e04944e8299efcaa098a","maxBlockNumber":18000025,"transaction":{},"fastMode":true,"seenInMempool":true,"simError":"execution reverted","isRevert":true}`))
+	}))
+	defer srv.Close()
+
+	var requests int
+	client := flashbots.NewProtectClient(srv.URL + "/tx/")
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests++
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+	gotStatus, err := client.TxStatus(context.Background(), txHash)
+	if err != nil {
+		t.Fatalf("Failed to get status: %v", err)
+	}
+
+	wantStatus := &flashbots.PrivateTxStatus{
+		Status:         flashbots.TxStatusFailed,
+		Hash:           txHash,
+		MaxBlockNumber: 18_000_025,
+		FastMode:       true,
+		SeenInMempool:  true,
+		SimError:       "execution reverted",
+		IsRevert:       true,
+	}
+	if diff := cmp.Diff(wantStatus, gotStatus); diff != "" {
+		t.Fatalf("(-want, +got)\n%s", diff)
+	}
+	if requests != 1 {
+		t.Fatalf("Requests: want 1, got %d", requests)
+	}
+
+	// unknown hash
+	_, err = client.TxStatus(context.Background(), w3.H("0x0000000000000000000000000000000000000000000000000000000000000001"))
+	if want := `flashbots: unexpected status "404 Not Found"`; err == nil || err.Error() != want {
+		t.Fatalf("Err: want %q, got %v", want, err)
+	}
+}
+
+func TestProtectClientWaitPrivateTx(t *testing.T) {
+	// respond with status UNKNOWN, PENDING, PENDING, INCLUDED
+	var polls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch polls.Add(1) {
+		case 1:
+			w.Write([]byte(`{"status":"UNKNOWN","hash":"0x669b4704a7d993a946cdd6e2f95233f308ce0c4649d2e04944e8299efcaa098a"}`))
+		case 2, 3:
+			w.Write([]byte(`{"status":"PENDING","hash":"0x669b4704a7d993a946cdd6e2f95233f308ce0c4649d2e04944e8299efcaa098a","maxBlockNumber":18000025}`))
+		default:
+			w.Write([]byte(`{"status":"INCLUDED","hash":"0x669b4704a7d993a946cdd6e2f95233f308ce0c4649d2e04944e8299efcaa098a","maxBlockNumber":18000025,"seenInMempool":false}`))
+		}
+	}))
+	defer srv.Close()
+
+	client := flashbots.NewProtectClient(srv.URL)
+	client.PollInterval = time.Millisecond
+
+	t.Run("included", func(t *testing.T) {
+		gotStatus, err := client.WaitPrivateTx(context.Background(), txHash)
+		if err != nil {
+			t.Fatalf("Failed to wait: %v", err)
+		}
+		if gotStatus.Status != flashbots.TxStatusIncluded {
+			t.Fatalf("Status: want %s, got %s", flashbots.TxStatusIncluded, gotStatus.Status)
+		}
+		if gotPolls := polls.Load(); gotPolls != 4 {
+			t.Fatalf("Polls: want 4, got %d", gotPolls)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		polls.Store(1)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		client := flashbots.NewProtectClient(srv.URL)
+		client.PollInterval = time.Hour
+		if _, err := client.WaitPrivateTx(ctx, txHash); err != context.DeadlineExceeded {
+			t.Fatalf("Err: want %v, got %v", context.DeadlineExceeded, err)
+		}
+	})
+}
@@ -0,0 +1,192 @@
+package flashbots_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/flashbots"
+	"github.com/lmittmann/w3"
+)
+
+func TestProtectConfig(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Config  *flashbots.ProtectConfig
+		BaseURL string
+		WantURL string
+	}{
+		{
+			Name:    "empty",
+			Config:  &flashbots.ProtectConfig{},
+			WantURL: "https://rpc.flashbots.net",
+		},
+		{
+			Name: "full",
+			Config: &flashbots.ProtectConfig{
+				Hints:    []flashbots.Hint{flashbots.HintCalldata, flashbots.HintLogs, flashbots.HintHash},
+				Builders: []string{"flashbots", "beaverbuild.org"},
+				Refunds: []flashbots.ProtectRefund{
+					{Address: w3.A("0x000000000000000000000000000000000000c0Fe"), Percent: 90},
+				},
+				OriginID:   "wallet",
+				Fast:       true,
+				UseMempool: true,
+			},
+			WantURL: "https://rpc.flashbots.net?builder=flashbots&builder=beaverbuild.org&fast=true&hint=calldata&hint=logs&hint=hash&originId=wallet&refund=0x000000000000000000000000000000000000c0Fe%3A90&useMempool=true",
+		},
+		{
+			Name: "base_url",
+			Config: &flashbots.ProtectConfig{
+				Builders: []string{"flashbots"},
+			},
+			BaseURL: "https://rpc-sepolia.flashbots.net/?builder=titan&foo=bar",
+			WantURL: "https://rpc-sepolia.flashbots.net/?builder=flashbots&foo=bar",
+		},
+		{
+			Name:    "base_url_fast_path",
+			Config:  &flashbots.ProtectConfig{},
+			BaseURL: "https://rpc.flashbots.net/fast",
+			WantURL: "https://rpc.flashbots.net",
+		},
+		{
+			Name:    "base_url_fast_path_fast",
+			Config:  &flashbots.ProtectConfig{Fast: true},
+			BaseURL: "https://rpc.flashbots.net/fast/",
+			WantURL: "https://rpc.flashbots.net?fast=true",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			gotURL, err := test.Config.URL(test.BaseURL)
+			if err != nil {
+				t.Fatalf("Failed to render URL: %v", err)
+			}
+			if test.WantURL != gotURL {
+				t.Fatalf("URL: want %s, got %s", test.WantURL, gotURL)
+			}
+
+			gotConfig, err := flashbots.ParseProtectURL(gotURL)
+			if err != nil {
+				t.Fatalf("Failed to parse URL: %v", err)
+			}
+			if diff := cmp.Diff(test.Config, gotConfig); diff != "" {
+				t.Fatalf("Config (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseProtectURL(t *testing.T) {
+	tests := []struct {
+		URL        string
+		WantConfig *flashbots.ProtectConfig
+		WantErr    string
+	}{
+		{
+			URL:        "https://rpc.flashbots.net/fast",
+			WantConfig: &flashbots.ProtectConfig{Fast: true},
+		},
+		{
+			URL: "https://rpc.flashbots.net?hint=calldata&hint=logs&refund=0x000000000000000000000000000000000000c0fe:50&useMempool=1",
+			WantConfig: &flashbots.ProtectConfig{
+				Hints:      []flashbots.Hint{flashbots.HintCalldata, flashbots.HintLogs},
+				Refunds:    []flashbots.ProtectRefund{{Address: common.HexToAddress("0xc0fe"), Percent: 50}},
+				UseMempool: true,
+			},
+		},
+		{
+			URL:     "https://rpc.flashbots.net?refund=0xc0fe",
+			WantErr: `flashbots: invalid refund "0xc0fe"`,
+		},
+		{
+			URL:     "https://rpc.flashbots.net?refund=0x000000000000000000000000000000000000c0fe:all",
+			WantErr: `flashbots: invalid refund "0x000000000000000000000000000000000000c0fe:all"`,
+		},
+		{
+			URL:     "https://rpc.flashbots.net?fast=yes",
+			WantErr: `flashbots: invalid fast "yes"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.URL, func(t *testing.T) {
+			gotConfig, err := flashbots.ParseProtectURL(test.URL)
+			if test.WantErr != "" {
+				if err == nil || err.Error() != test.WantErr {
+					t.Fatalf("Err: want %q, got %v", test.WantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to parse URL: %v", err)
+			}
+			if diff := cmp.Diff(test.WantConfig, gotConfig); diff != "" {
+				t.Fatalf("(-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithProtectConfig(t *testing.T) {
+	prv, _ := crypto.GenerateKey()
+
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":true}`))
+	}))
+	defer srv.Close()
+
+	client := flashbots.MustDial(srv.URL, prv, flashbots.WithProtectConfig(&flashbots.ProtectConfig{
+		Hints: []flashbots.Hint{flashbots.HintHash},
+		Fast:  true,
+	}))
+	defer client.Close()
+
+	var canceled bool
+	if err := client.Call(flashbots.CancelPrivateTx(common.Hash{}).Returns(&canceled)); err != nil {
+		t.Fatalf("Failed to call: %v", err)
+	}
+	if want := "fast=true&hint=hash"; want != gotQuery {
+		t.Fatalf("Query: want %q, got %q", want, gotQuery)
+	}
+}
+
+func TestDialProtect(t *testing.T) {
+	var (
+		gotQuery string
+		gotSig   string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		gotSig = r.Header.Get("X-Flashbots-Signature")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":true}`))
+	}))
+	defer srv.Close()
+
+	client, err := flashbots.DialProtect(srv.URL, flashbots.WithProtectConfig(&flashbots.ProtectConfig{
+		Builders: []string{"flashbots"},
+	}))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer client.Close()
+
+	var canceled bool
+	if err := client.Call(flashbots.CancelPrivateTx(common.Hash{}).Returns(&canceled)); err != nil {
+		t.Fatalf("Failed to call: %v", err)
+	}
+	if want := "builder=flashbots"; want != gotQuery {
+		t.Fatalf("Query: want %q, got %q", want, gotQuery)
+	}
+	if gotSig != "" {
+		t.Fatalf("Signature: want none, got %q", gotSig)
+	}
+}
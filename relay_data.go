@@ -0,0 +1,317 @@
+package flashbots
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/flashbots/internal"
+)
+
+// RelayURL is the URL of the Flashbots MEV-Boost relay.
+const RelayURL = "https://boost-relay.flashbots.net"
+
+// RelayClient is a client of the data API of one or more MEV-Boost relays.
+// Queries are sent to all relays concurrently and their results are
+// aggregated in the order of the relays.
+type RelayClient struct {
+	// HTTPClient is the client used to send requests. The [http.DefaultClient]
+	// is used if nil.
+	HTTPClient *http.Client
+
+	urls []string
+}
+
+// NewRelayClient returns a new client of the data API of the relays with the
+// given URLs (e.g. [RelayURL]).
+func NewRelayClient(urls ...string) *RelayClient {
+	c := &RelayClient{urls: make([]string, len(urls))}
+	for i, u := range urls {
+		c.urls[i] = strings.TrimSuffix(u, "/")
+	}
+	return c
+}
+
+// BidTraceQuery filters the bid traces returned by the relay data API. Zero
+// fields are not used as filter.
+type BidTraceQuery struct {
+	Slot           uint64      // Slot of the block.
+	Cursor         uint64      // Return only bid traces of slots up to the cursor (only [RelayClient.PayloadsDelivered]).
+	Limit          int         // Max number of bid traces returned per relay.
+	BlockHash      common.Hash // Hash of the block.
+	BlockNumber    *big.Int    // Number of the block.
+	BuilderPubkey  string      // BLS public key of the builder.
+	ProposerPubkey string      // BLS public key of the proposer (only [RelayClient.PayloadsDelivered]).
+	OrderBy        string      // Order of the bid traces, "value" or "-value" (only [RelayClient.PayloadsDelivered]).
+}
+
+func (q *BidTraceQuery) values() url.Values {
+	v := make(url.Values)
+	if q == nil {
+		return v
+	}
+	if q.Slot > 0 {
+		v.Set("slot", strconv.FormatUint(q.Slot, 10))
+	}
+	if q.Cursor > 0 {
+		v.Set("cursor", strconv.FormatUint(q.Cursor, 10))
+	}
+	if q.Limit > 0 {
+		v.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.BlockHash != (common.Hash{}) {
+		v.Set("block_hash", q.BlockHash.Hex())
+	}
+	if q.BlockNumber != nil {
+		v.Set("block_number", q.BlockNumber.String())
+	}
+	if q.BuilderPubkey != "" {
+		v.Set("builder_pubkey", q.BuilderPubkey)
+	}
+	if q.ProposerPubkey != "" {
+		v.Set("proposer_pubkey", q.ProposerPubkey)
+	}
+	if q.OrderBy != "" {
+		v.Set("order_by", q.OrderBy)
+	}
+	return v
+}
+
+// BidTrace is a bid of a builder for a slot, as returned by the relay data API.
+type BidTrace struct {
+	Relay                string // URL of the relay that returned the bid trace.
+	Slot                 uint64
+	ParentHash           common.Hash
+	BlockHash            common.Hash
+	BuilderPubkey        string // BLS public key of the builder.
+	ProposerPubkey       string // BLS public key of the proposer.
+	ProposerFeeRecipient common.Address
+	GasLimit             uint64
+	GasUsed              uint64
+	Value                *big.Int  // Value of the bid paid to the proposer.
+	BlockNumber          uint64    // Number of the block.
+	NumTx                uint64    // Number of transactions in the block.
+	Timestamp            time.Time // Time the relay received the bid (only [RelayClient.BuilderBlocksReceived]).
+	OptimisticSubmission bool      // Bid was submitted optimistically (only [RelayClient.BuilderBlocksReceived]).
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (b *BidTrace) UnmarshalJSON(input []byte) error {
+	type bidTrace struct {
+		Slot                 uint64           `json:"slot,string"`
+		ParentHash           common.Hash      `json:"parent_hash"`
+		BlockHash            common.Hash      `json:"block_hash"`
+		BuilderPubkey        string           `json:"builder_pubkey"`
+		ProposerPubkey       string           `json:"proposer_pubkey"`
+		ProposerFeeRecipient common.Address   `json:"proposer_fee_recipient"`
+		GasLimit             uint64           `json:"gas_limit,string"`
+		GasUsed              uint64           `json:"gas_used,string"`
+		Value                *internal.StrInt `json:"value"`
+		BlockNumber          uint64           `json:"block_number,string"`
+		NumTx                uint64           `json:"num_tx,string"`
+		Timestamp            int64            `json:"timestamp,string"`
+		TimestampMs          int64            `json:"timestamp_ms,string"`
+		OptimisticSubmission bool             `json:"optimistic_submission"`
+	}
+
+	var dec bidTrace
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+
+	b.Slot = dec.Slot
+	b.ParentHash = dec.ParentHash
+	b.BlockHash = dec.BlockHash
+	b.BuilderPubkey = dec.BuilderPubkey
+	b.ProposerPubkey = dec.ProposerPubkey
+	b.ProposerFeeRecipient = dec.ProposerFeeRecipient
+	b.GasLimit = dec.GasLimit
+	b.GasUsed = dec.GasUsed
+	b.Value = (*big.Int)(dec.Value)
+	b.BlockNumber = dec.BlockNumber
+	b.NumTx = dec.NumTx
+	switch {
+	case dec.TimestampMs > 0:
+		b.Timestamp = time.UnixMilli(dec.TimestampMs).UTC()
+	case dec.Timestamp > 0:
+		b.Timestamp = time.Unix(dec.Timestamp, 0).UTC()
+	}
+	b.OptimisticSubmission = dec.OptimisticSubmission
+	return nil
+}
+
+// ValidatorRegistration is the registration of a validator at a relay.
+type ValidatorRegistration struct {
+	Relay        string // URL of the relay that returned the registration.
+	Pubkey       string // BLS public key of the validator.
+	FeeRecipient common.Address
+	GasLimit     uint64
+	Timestamp    time.Time
+	Signature    string // BLS signature of the registration.
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (v *ValidatorRegistration) UnmarshalJSON(input []byte) error {
+	type validatorRegistration struct {
+		Message struct {
+			FeeRecipient common.Address `json:"fee_recipient"`
+			GasLimit     uint64         `json:"gas_limit,string"`
+			Timestamp    int64          `json:"timestamp,string"`
+			Pubkey       string         `json:"pubkey"`
+		} `json:"message"`
+		Signature string `json:"signature"`
+	}
+
+	var dec validatorRegistration
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+
+	v.Pubkey = dec.Message.Pubkey
+	v.FeeRecipient = dec.Message.FeeRecipient
+	v.GasLimit = dec.Message.GasLimit
+	v.Timestamp = time.Unix(dec.Message.Timestamp, 0).UTC()
+	v.Signature = dec.Signature
+	return nil
+}
+
+// PayloadsDelivered returns the bid traces of the payloads delivered by the
+// relays to proposers that match the given query.
+//
+// The bid traces of all relays that responded successfully are returned, even
+// if the query failed for some relays. The errors of the failed relays are
+// joined in the returned error.
+func (c *RelayClient) PayloadsDelivered(ctx context.Context, q *BidTraceQuery) ([]*BidTrace, error) {
+	return queryBidTraces(ctx, c, "/relay/v1/data/bidtraces/proposer_payload_delivered", q)
+}
+
+// BuilderBlocksReceived returns the bid traces of the blocks submitted by
+// builders to the relays that match the given query. At least one of Slot,
+// BlockHash, BlockNumber or BuilderPubkey must be set.
+//
+// See [RelayClient.PayloadsDelivered] for the handling of errors.
+func (c *RelayClient) BuilderBlocksReceived(ctx context.Context, q *BidTraceQuery) ([]*BidTrace, error) {
+	return queryBidTraces(ctx, c, "/relay/v1/data/bidtraces/builder_blocks_received", q)
+}
+
+// ValidatorRegistrations returns the registrations of the validator with the
+// given BLS public key at the relays. Relays at which the validator is not
+// registered, i.e. that respond with status 400 "no registration found" or with
+// null, are skipped.
+//
+// See [RelayClient.PayloadsDelivered] for the handling of errors.
+func (c *RelayClient) ValidatorRegistrations(ctx context.Context, pubkey string) ([]*ValidatorRegistration, error) {
+	return queryRelays(ctx, c, "/relay/v1/data/validator_registration", url.Values{"pubkey": {pubkey}}, isNoRegistration,
+		func(relay string, reg *ValidatorRegistration) []*ValidatorRegistration {
+			if reg == nil {
+				return nil // validator not registered at the relay
+			}
+			reg.Relay = relay
+			return []*ValidatorRegistration{reg}
+		},
+	)
+}
+
+func queryBidTraces(ctx context.Context, c *RelayClient, path string, q *BidTraceQuery) ([]*BidTrace, error) {
+	return queryRelays(ctx, c, path, q.values(), nil, func(relay string, traces []*BidTrace) []*BidTrace {
+		for _, trace := range traces {
+			trace.Relay = relay
+		}
+		return traces
+	})
+}
+
+// isNoRegistration returns true if err is the response of a relay for a
+// validator that is not registered at the relay.
+func isNoRegistration(err error) bool {
+	var statusErr *statusError
+	return errors.As(err, &statusErr) &&
+		statusErr.StatusCode == http.StatusBadRequest &&
+		strings.HasPrefix(strings.ToLower(statusErr.Message), "no registration found")
+}
+
+// queryRelays sends the GET request to all relays concurrently and aggregates
+// the decoded responses in the order of the relays. Errors for which ignore
+// returns true are not returned. ignore may be nil.
+func queryRelays[R, T any](ctx context.Context, c *RelayClient, path string, query url.Values, ignore func(error) bool, collect func(relay string, resp R) []T) ([]T, error) {
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var (
+		wg      sync.WaitGroup
+		results = make([][]T, len(c.urls))
+		errs    = make([]error, len(c.urls))
+	)
+	for i, relay := range c.urls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var resp R
+			if err := getJSON(ctx, c.HTTPClient, relay+path, &resp); err != nil {
+				if ignore == nil || !ignore(err) {
+					errs[i] = fmt.Errorf("%s: %w", relay, err)
+				}
+				return
+			}
+			results[i] = collect(relay, resp)
+		}()
+	}
+	wg.Wait()
+
+	var all []T
+	for _, res := range results {
+		all = append(all, res...)
+	}
+	return all, errors.Join(errs...)
+}
+
+// statusError is a non-200 response of a relay.
+type statusError struct {
+	StatusCode int
+	Status     string
+	Message    string // message of the JSON error response, if any
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("flashbots: unexpected status %q", e.Status)
+}
+
+// getJSON sends a GET request to the given URL with the given client and
+// decodes the JSON response into v. The [http.DefaultClient] is used if client
+// is nil. A non-200 response is returned as [*statusError].
+func getJSON(ctx context.Context, client *http.Client, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Message string `json:"message"`
+		}
+		json.NewDecoder(io.LimitReader(resp.Body, maxErrorBodySize)).Decode(&errResp)
+		return &statusError{StatusCode: resp.StatusCode, Status: resp.Status, Message: errResp.Message}
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,168 @@
+package flashbots_test
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/flashbots"
+	"github.com/lmittmann/w3"
+)
+
+const (
+	builderPubkey  = "0xa1dead01e65f0a0eee7b5170223f20c8f0cbf122eac3324d61afbdb33a8885ff8cab2ef514ac2c7698ae0d6289ef27fc"
+	proposerPubkey = "0x8f1e2c8e12d0e3cd5bd0d5d9a6ab2cb9d1b9f6e3e4ad6a7df4c1c8c6f1c1b5b9a2dfd3e6d7e6b6f4d8f1a1e3c6b9d2e4f"
+)
+
+// newRelay starts a mock relay that serves the fixtures in testdata/relay for
+// the given request URIs (path and query). Unknown URIs are answered with
+// status 400.
+func newRelay(t *testing.T, fixtures map[string]string) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fixture, ok := fixtures[r.URL.RequestURI()]
+		if !ok {
+			http.Error(w, `{"code":400,"message":"no data"}`, http.StatusBadRequest)
+			return
+		}
+		data, err := os.ReadFile("testdata/relay/" + fixture)
+		if err != nil {
+			t.Errorf("Failed to read fixture: %v", err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func wantBidTrace(relay string) *flashbots.BidTrace {
+	return &flashbots.BidTrace{
+		Relay:                relay,
+		Slot:                 7_500_000,
+		ParentHash:           w3.H("0x2a76e4a8c2b1be2e5aa2ea95bb2d6d4b1a0ec6e4d1fbf21ad5d2f3a9b3b8e0c1"),
+		BlockHash:            w3.H("0x7f3f0ba1bb2e8e9a5fd27c2ec8c2c3b71b4ac53ad3bc9f4ebc36df4e9c3fc6a5"),
+		BuilderPubkey:        builderPubkey,
+		ProposerPubkey:       proposerPubkey,
+		ProposerFeeRecipient: w3.A("0x388C818CA8B9251b393131C08a736A67ccB19297"),
+		GasLimit:             30_000_000,
+		GasUsed:              14_999_123,
+		Value:                w3.I("0.051234 ether"),
+		BlockNumber:          18_284_000,
+		NumTx:                142,
+	}
+}
+
+func TestRelayClientPayloadsDelivered(t *testing.T) {
+	relayA := newRelay(t, map[string]string{
+		"/relay/v1/data/bidtraces/proposer_payload_delivered?block_number=18284000": "proposer_payload_delivered_a.json",
+	})
+	relayB := newRelay(t, map[string]string{
+		"/relay/v1/data/bidtraces/proposer_payload_delivered?block_number=18284000": "proposer_payload_delivered_b.json",
+	})
+
+	client := flashbots.NewRelayClient(relayA.URL, relayB.URL+"/")
+	gotTraces, err := client.PayloadsDelivered(context.Background(), &flashbots.BidTraceQuery{
+		BlockNumber: big.NewInt(18_284_000),
+	})
+	if err != nil {
+		t.Fatalf("Failed to query: %v", err)
+	}
+
+	wantTraces := []*flashbots.BidTrace{wantBidTrace(relayA.URL), wantBidTrace(relayB.URL)}
+	if diff := cmp.Diff(wantTraces, gotTraces,
+		cmp.Comparer(func(x, y *big.Int) bool { return x.Cmp(y) == 0 }),
+	); diff != "" {
+		t.Fatalf("(-want, +got)\n%s", diff)
+	}
+}
+
+func TestRelayClientBuilderBlocksReceived(t *testing.T) {
+	relay := newRelay(t, map[string]string{
+		"/relay/v1/data/bidtraces/builder_blocks_received?builder_pubkey=" + builderPubkey + "&slot=7500000": "builder_blocks_received.json",
+	})
+
+	client := flashbots.NewRelayClient(relay.URL)
+	gotTraces, err := client.BuilderBlocksReceived(context.Background(), &flashbots.BidTraceQuery{
+		Slot:          7_500_000,
+		BuilderPubkey: builderPubkey,
+	})
+	if err != nil {
+		t.Fatalf("Failed to query: %v", err)
+	}
+
+	wantTrace := wantBidTrace(relay.URL)
+	wantTrace.Timestamp = time.UnixMilli(1_696_000_011_123).UTC()
+	wantTrace.OptimisticSubmission = true
+	if diff := cmp.Diff([]*flashbots.BidTrace{wantTrace}, gotTraces,
+		cmp.Comparer(func(x, y *big.Int) bool { return x.Cmp(y) == 0 }),
+	); diff != "" {
+		t.Fatalf("(-want, +got)\n%s", diff)
+	}
+}
+
+func TestRelayClientValidatorRegistrations(t *testing.T) {
+	relayA := newRelay(t, map[string]string{
+		"/relay/v1/data/validator_registration?pubkey=" + proposerPubkey: "validator_registration.json",
+	})
+	relayB := newRelay(t, nil)
+	relayC := newRelay(t, map[string]string{
+		"/relay/v1/data/validator_registration?pubkey=" + proposerPubkey: "validator_registration_null.json",
+	})
+	relayD := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"code":400,"message":"no registration found for validator `+proposerPubkey+`"}`, http.StatusBadRequest)
+	}))
+	defer relayD.Close()
+
+	client := flashbots.NewRelayClient(relayA.URL, relayB.URL, relayC.URL, relayD.URL)
+	gotRegs, err := client.ValidatorRegistrations(context.Background(), proposerPubkey)
+
+	// the registration of relay A is returned despite the error of relay B and
+	// the missing registrations at relay C and D
+	wantErr := relayB.URL + `: flashbots: unexpected status "400 Bad Request"`
+	if err == nil || err.Error() != wantErr {
+		t.Fatalf("Err: want %q, got %v", wantErr, err)
+	}
+	if len(gotRegs) != 1 {
+		t.Fatalf("Want 1 registration, got %d", len(gotRegs))
+	}
+
+	wantReg := &flashbots.ValidatorRegistration{
+		Relay:        relayA.URL,
+		Pubkey:       proposerPubkey,
+		FeeRecipient: w3.A("0x388C818CA8B9251b393131C08a736A67ccB19297"),
+		GasLimit:     30_000_000,
+		Timestamp:    time.Unix(1_695_000_000, 0).UTC(),
+		Signature:    "0xb2a1c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90",
+	}
+	if diff := cmp.Diff(wantReg, gotRegs[0]); diff != "" {
+		t.Fatalf("(-want, +got)\n%s", diff)
+	}
+}
+
+func TestRelayClientHTTPClient(t *testing.T) {
+	relay := newRelay(t, map[string]string{
+		"/relay/v1/data/validator_registration?pubkey=" + proposerPubkey: "validator_registration.json",
+	})
+
+	var requests int
+	client := flashbots.NewRelayClient(relay.URL)
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests++
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+
+	if _, err := client.ValidatorRegistrations(context.Background(), proposerPubkey); err != nil {
+		t.Fatalf("Failed to query: %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("Requests: want 1, got %d", requests)
+	}
+}
@@ -0,0 +1,209 @@
+package flashbots
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3"
+)
+
+// Resubmitter sends a bundle for every new block until it is included, one of
+// its tx nonces is consumed, or the max block number or deadline is reached.
+type Resubmitter struct {
+	// MaxBlockNumber is the last block the bundle is sent for (Optional).
+	MaxBlockNumber *big.Int
+
+	// Deadline is the point in time after which the bundle is no longer sent
+	// (Optional).
+	Deadline time.Time
+
+	// Simulate enables the simulation of the bundle via [CallBundle] before it
+	// is sent. The bundle is not sent for a block if any of its txs that are
+	// not allowed to revert reverts.
+	Simulate bool
+
+	// Rebuild is called before the bundle is sent for the given block number
+	// with the txs that were last sent. The returned txs are sent instead, e.g.
+	// to bump tips (Optional).
+	//
+	// The hashes in RevertingTxHashes, DroppingTxHashes and RefundTxHashes of
+	// the template are mapped by index to the hashes of the returned txs.
+	Rebuild func(ctx context.Context, blockNumber *big.Int, txs types.Transactions) (types.Transactions, error)
+
+	client   *w3.Client
+	src      ChainSource
+	template *SendBundleRequest
+}
+
+// NewResubmitter returns a new [Resubmitter] that sends the bundle template via
+// the given client for every new block of the given chain source. The
+// template's BlockNumber is the first block the bundle is sent for. If it is
+// nil, the bundle is sent starting with the next block.
+func NewResubmitter(client *w3.Client, src ChainSource, template *SendBundleRequest) *Resubmitter {
+	return &Resubmitter{
+		client:   client,
+		src:      src,
+		template: template,
+	}
+}
+
+// Run sends the bundle for every new block and returns the outcome of the last
+// block it was sent for, once the bundle was at least partially included or
+// one of its tx nonces was consumed. [OutcomeExpired] is returned if the max
+// block number or deadline was reached before.
+//
+// An error is returned if ctx is canceled, or if the bundle could not be
+// rebuilt, simulated, or sent.
+func (rs *Resubmitter) Run(ctx context.Context) (Outcome, error) {
+	templateTxs, err := rs.template.txs()
+	if err != nil {
+		return Outcome{}, err
+	}
+	txs := templateTxs
+
+	heads := make(chan *types.Header)
+	sub, err := rs.src.SubscribeNewHeads(ctx, heads)
+	if err != nil {
+		return Outcome{}, err
+	}
+	defer sub.Unsubscribe()
+
+	var deadline <-chan time.Time
+	if !rs.Deadline.IsZero() {
+		timer := time.NewTimer(time.Until(rs.Deadline))
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	var sent *SendBundleRequest // last sent bundle
+	for {
+		select {
+		case head := <-heads:
+			// check the outcome of the last sent bundle
+			if sent != nil && sent.BlockNumber.Cmp(head.Number) <= 0 {
+				outcome := bundleOutcome(ctx, rs.src, sent, sent.Transactions)
+				if outcome.Err != nil {
+					return outcome, outcome.Err
+				}
+				if outcome.Status != OutcomeMissed && outcome.Status != OutcomeExpired {
+					return outcome, nil
+				}
+				sent = nil
+			}
+
+			consumed, err := nonceConsumed(ctx, rs.src, txs, head.Number)
+			if err != nil {
+				return Outcome{}, err
+			}
+			if consumed {
+				return Outcome{
+					Status:      OutcomeNonceInvalidated,
+					BlockNumber: head.Number,
+					BlockHash:   head.Hash(),
+				}, nil
+			}
+
+			blockNumber := new(big.Int).Add(head.Number, w3.Big1)
+			if rs.template.BlockNumber != nil && blockNumber.Cmp(rs.template.BlockNumber) < 0 {
+				continue
+			}
+			if rs.MaxBlockNumber != nil && blockNumber.Cmp(rs.MaxBlockNumber) > 0 {
+				return Outcome{
+					Status:      OutcomeExpired,
+					BlockNumber: head.Number,
+					BlockHash:   head.Hash(),
+				}, nil
+			}
+
+			if rs.Rebuild != nil {
+				if txs, err = rs.Rebuild(ctx, blockNumber, txs); err != nil {
+					return Outcome{}, err
+				}
+			}
+
+			r := *rs.template
+			r.Transactions = txs
+			r.RawTransactions = nil
+			r.BlockNumber = blockNumber
+			if rs.Rebuild != nil {
+				r.RevertingTxHashes = remapTxHashes(rs.template.RevertingTxHashes, templateTxs, txs)
+				r.DroppingTxHashes = remapTxHashes(rs.template.DroppingTxHashes, templateTxs, txs)
+				r.RefundTxHashes = remapTxHashes(rs.template.RefundTxHashes, templateTxs, txs)
+			}
+
+			if rs.Simulate {
+				ok, err := rs.simulate(ctx, &r, head.Number)
+				if err != nil {
+					return Outcome{}, err
+				}
+				if !ok {
+					continue
+				}
+			}
+
+			if err := rs.client.CallCtx(ctx, SendBundle(&r).Returns(nil)); err != nil {
+				return Outcome{}, err
+			}
+			sent = &r
+		case err := <-sub.Err():
+			return Outcome{}, err
+		case <-deadline:
+			return Outcome{Status: OutcomeExpired}, nil
+		case <-ctx.Done():
+			return Outcome{}, ctx.Err()
+		}
+	}
+}
+
+// remapTxHashes maps the given hashes of txs in oldTxs to the hashes of the
+// txs with the same index in newTxs. Hashes of txs that are not in oldTxs are
+// kept, hashes of txs without counterpart in newTxs are dropped.
+func remapTxHashes(hashes []common.Hash, oldTxs, newTxs types.Transactions) []common.Hash {
+	if len(hashes) == 0 {
+		return hashes
+	}
+
+	index := make(map[common.Hash]int, len(oldTxs))
+	for i, tx := range oldTxs {
+		index[tx.Hash()] = i
+	}
+
+	remapped := make([]common.Hash, 0, len(hashes))
+	for _, hash := range hashes {
+		i, ok := index[hash]
+		switch {
+		case !ok:
+			remapped = append(remapped, hash)
+		case i < len(newTxs):
+			remapped = append(remapped, newTxs[i].Hash())
+		}
+	}
+	return remapped
+}
+
+// simulate returns true if none of the txs of the bundle that are not allowed
+// to revert reverts on top of the given state block.
+func (rs *Resubmitter) simulate(ctx context.Context, r *SendBundleRequest, stateBlockNumber *big.Int) (bool, error) {
+	var resp *CallBundleResponse
+	if err := rs.client.CallCtx(ctx, CallBundle(&CallBundleRequest{
+		Transactions:     r.Transactions,
+		BlockNumber:      r.BlockNumber,
+		StateBlockNumber: stateBlockNumber,
+	}).Returns(&resp)); err != nil {
+		return false, err
+	}
+
+	reverting := make(map[common.Hash]bool, len(r.RevertingTxHashes))
+	for _, txHash := range r.RevertingTxHashes {
+		reverting[txHash] = true
+	}
+	for _, res := range resp.Results {
+		if res.Error != nil && !reverting[res.TxHash] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
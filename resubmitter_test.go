@@ -0,0 +1,206 @@
+package flashbots_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/flashbots"
+	"github.com/lmittmann/flashbots/flashbotstest"
+)
+
+func TestResubmitterRun(t *testing.T) {
+	prv, _ := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+
+	// rebuild bumps the tip of the tx to the target block number
+	rebuild := func(ctx context.Context, blockNumber *big.Int, txs types.Transactions) (types.Transactions, error) {
+		return types.Transactions{newTx(prv, 0, blockNumber.Int64())}, nil
+	}
+
+	tests := []struct {
+		Name             string
+		Reverting        bool // allow the template tx to revert
+		Configure        func(*flashbotstest.Server, *flashbots.Resubmitter)
+		Blocks           []types.Transactions
+		WantOutcome      flashbots.Outcome
+		WantBlockNumbers []int64 // block numbers of the sent bundles
+	}{
+		{
+			Name: "included",
+			Configure: func(srv *flashbotstest.Server, rs *flashbots.Resubmitter) {
+				rs.Rebuild = rebuild
+			},
+			Blocks: []types.Transactions{{}, {}, {newTx(prv, 0, 3)}},
+			WantOutcome: flashbots.Outcome{
+				Status:      flashbots.OutcomeIncluded,
+				BlockNumber: big.NewInt(3),
+				TxHashes:    []common.Hash{newTx(prv, 0, 3).Hash()},
+			},
+			WantBlockNumbers: []int64{2, 3},
+		},
+		{
+			Name: "nonce_invalidated",
+			Configure: func(srv *flashbotstest.Server, rs *flashbots.Resubmitter) {
+				rs.Rebuild = rebuild
+			},
+			Blocks: []types.Transactions{{}, {newTx(prv, 0, 100)}},
+			WantOutcome: flashbots.Outcome{
+				Status:      flashbots.OutcomeNonceInvalidated,
+				BlockNumber: big.NewInt(2),
+			},
+			WantBlockNumbers: []int64{2},
+		},
+		{
+			Name: "max_block_number",
+			Configure: func(srv *flashbotstest.Server, rs *flashbots.Resubmitter) {
+				rs.MaxBlockNumber = big.NewInt(3)
+			},
+			Blocks: []types.Transactions{{}, {}, {}},
+			WantOutcome: flashbots.Outcome{
+				Status:      flashbots.OutcomeExpired,
+				BlockNumber: big.NewInt(3),
+			},
+			WantBlockNumbers: []int64{2, 3},
+		},
+		{
+			Name: "simulate",
+			Configure: func(srv *flashbotstest.Server, rs *flashbots.Resubmitter) {
+				rs.Simulate = true
+				rs.Rebuild = rebuild
+
+				// revert the first simulation
+				var calls int
+				srv.HandleFunc("eth_callBundle", func(signer common.Address, params []json.RawMessage) (any, error) {
+					calls++
+					result := map[string]any{"txHash": common.Hash{}}
+					if calls == 1 {
+						result["error"] = "execution reverted"
+					}
+					return map[string]any{"results": []any{result}}, nil
+				})
+			},
+			Blocks: []types.Transactions{{}, {}, {newTx(prv, 0, 3)}},
+			WantOutcome: flashbots.Outcome{
+				Status:      flashbots.OutcomeIncluded,
+				BlockNumber: big.NewInt(3),
+				TxHashes:    []common.Hash{newTx(prv, 0, 3).Hash()},
+			},
+			WantBlockNumbers: []int64{3},
+		},
+		{
+			Name:      "simulate_rebuilt_reverting",
+			Reverting: true,
+			Configure: func(srv *flashbotstest.Server, rs *flashbots.Resubmitter) {
+				rs.Simulate = true
+				rs.Rebuild = rebuild
+
+				// revert every simulated tx
+				srv.HandleFunc("eth_callBundle", func(signer common.Address, params []json.RawMessage) (any, error) {
+					var r flashbots.CallBundleRequest
+					if err := json.Unmarshal(params[0], &r); err != nil {
+						return nil, err
+					}
+					var results []any
+					for _, rawTx := range r.RawTransactions {
+						results = append(results, map[string]any{
+							"txHash": crypto.Keccak256Hash(rawTx),
+							"error":  "execution reverted",
+						})
+					}
+					return map[string]any{"results": results}, nil
+				})
+			},
+			Blocks: []types.Transactions{{}, {}, {newTx(prv, 0, 3)}},
+			WantOutcome: flashbots.Outcome{
+				Status:      flashbots.OutcomeIncluded,
+				BlockNumber: big.NewInt(3),
+				TxHashes:    []common.Hash{newTx(prv, 0, 3).Hash()},
+			},
+			WantBlockNumbers: []int64{2, 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			srv := flashbotstest.NewServer()
+			defer srv.Close()
+
+			client := flashbots.MustDial(srv.URL(), prv)
+			defer client.Close()
+
+			chain := &subscribedChain{Chain: flashbotstest.NewChain(), subscribed: make(chan struct{})}
+			template := &flashbots.SendBundleRequest{
+				Transactions: types.Transactions{newTx(prv, 0, 1)},
+			}
+			if test.Reverting {
+				template.RevertingTxHashes = []common.Hash{template.Transactions[0].Hash()}
+			}
+			rs := flashbots.NewResubmitter(client, chain, template)
+			test.Configure(srv, rs)
+
+			type result struct {
+				Outcome flashbots.Outcome
+				Err     error
+			}
+			results := make(chan result, 1)
+			go func() {
+				outcome, err := rs.Run(ctx)
+				results <- result{outcome, err}
+			}()
+
+			<-chain.subscribed
+			var blocks []*types.Block
+			for _, txs := range test.Blocks {
+				blocks = append(blocks, chain.AddBlock(txs...))
+			}
+
+			res := <-results
+			if res.Err != nil {
+				t.Fatalf("Failed to run: %v", res.Err)
+			}
+			test.WantOutcome.BlockHash = blocks[test.WantOutcome.BlockNumber.Int64()-1].Hash()
+			if diff := cmp.Diff(test.WantOutcome, res.Outcome,
+				cmp.AllowUnexported(big.Int{}),
+			); diff != "" {
+				t.Fatalf("Outcome (-want, +got)\n%s", diff)
+			}
+
+			var gotBlockNumbers []int64
+			for _, bundle := range srv.Bundles() {
+				gotBlockNumbers = append(gotBlockNumbers, bundle.Request.BlockNumber.Int64())
+
+				// reverting tx hashes must refer to the sent txs
+				if test.Reverting {
+					wantHashes := []common.Hash{crypto.Keccak256Hash(bundle.Request.RawTransactions[0])}
+					if diff := cmp.Diff(wantHashes, bundle.Request.RevertingTxHashes); diff != "" {
+						t.Fatalf("Reverting tx hashes (-want, +got)\n%s", diff)
+					}
+				}
+			}
+			if diff := cmp.Diff(test.WantBlockNumbers, gotBlockNumbers); diff != "" {
+				t.Fatalf("Block numbers (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+// subscribedChain closes subscribed after the first subscription to new heads.
+type subscribedChain struct {
+	*flashbotstest.Chain
+	subscribed chan struct{}
+}
+
+func (c *subscribedChain) SubscribeNewHeads(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	defer close(c.subscribed)
+	return c.Chain.SubscribeNewHeads(ctx, ch)
+}
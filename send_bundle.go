@@ -19,7 +19,14 @@ type SendBundleRequest struct {
 	MinTimestamp      uint64             // Minimum Unix Timestamp for which the bundle is valid
 	MaxTimestamp      uint64             // Maximum Unix Timestamp for which the bundle is valid
 	RevertingTxHashes []common.Hash      // List of tx hashes in bundle that are allowed to revert.
+	DroppingTxHashes  []common.Hash      // List of tx hashes in bundle that are allowed to be dropped from the bundle.
 	ReplacementUuid   uuid.UUID          // UUID that can be used to cancel/replace this bundle
+	ReplacementNonce  *uint64            // Nonce of the replacement; only the bundle with the highest nonce for a ReplacementUuid is considered (Optional).
+	RefundPercent     int                // Percentage of the bundles profit that is refunded (Optional).
+	RefundIndex       *int               // Index of the tx in bundle that receives the refund (Optional).
+	RefundRecipient   *common.Address    // Recipient of the refund, sender of the tx at RefundIndex if nil.
+	RefundTxHashes    []common.Hash      // List of tx hashes used to calculate the refund (Optional).
+	Builders          []string           // List of builders the bundle is shared with (Optional).
 }
 
 type sendBundleRequest struct {
@@ -28,7 +35,14 @@ type sendBundleRequest struct {
 	MinTimestamp      uint64          `json:"minTimestamp,omitempty"`
 	MaxTimestamp      uint64          `json:"maxTimestamp,omitempty"`
 	RevertingTxHashes []common.Hash   `json:"revertingTxHashes,omitempty"`
+	DroppingTxHashes  []common.Hash   `json:"droppingTxHashes,omitempty"`
 	ReplacementUuid   uuid.UUID       `json:"replacementUuid,omitempty"`
+	ReplacementNonce  *uint64         `json:"replacementNonce,omitempty"`
+	RefundPercent     int             `json:"refundPercent,omitempty"`
+	RefundIndex       *int            `json:"refundIndex,omitempty"`
+	RefundRecipient   *common.Address `json:"refundRecipient,omitempty"`
+	RefundTxHashes    []common.Hash   `json:"refundTxHashes,omitempty"`
+	Builders          []string        `json:"builders,omitempty"`
 }
 
 // MarshalJSON implements the [json.Marshaler].
@@ -56,10 +70,48 @@ func (s SendBundleRequest) MarshalJSON() ([]byte, error) {
 	enc.MinTimestamp = s.MinTimestamp
 	enc.MaxTimestamp = s.MaxTimestamp
 	enc.RevertingTxHashes = s.RevertingTxHashes
+	enc.DroppingTxHashes = s.DroppingTxHashes
 	enc.ReplacementUuid = s.ReplacementUuid
+	enc.ReplacementNonce = s.ReplacementNonce
+	enc.RefundPercent = s.RefundPercent
+	enc.RefundIndex = s.RefundIndex
+	enc.RefundRecipient = s.RefundRecipient
+	enc.RefundTxHashes = s.RefundTxHashes
+	enc.Builders = s.Builders
 	return json.Marshal(&enc)
 }
 
+// UnmarshalJSON implements the [json.Unmarshaler]. Transactions are decoded
+// into RawTransactions.
+func (s *SendBundleRequest) UnmarshalJSON(input []byte) error {
+	var dec sendBundleRequest
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+
+	s.RawTransactions = make([][]byte, len(dec.RawTransactions))
+	for i, rawTx := range dec.RawTransactions {
+		s.RawTransactions[i] = rawTx
+	}
+	s.BlockNumber = (*big.Int)(dec.BlockNumber)
+	s.MinTimestamp = dec.MinTimestamp
+	s.MaxTimestamp = dec.MaxTimestamp
+	s.RevertingTxHashes = dec.RevertingTxHashes
+	s.DroppingTxHashes = dec.DroppingTxHashes
+	s.ReplacementUuid = dec.ReplacementUuid
+	s.ReplacementNonce = dec.ReplacementNonce
+	s.RefundPercent = dec.RefundPercent
+	s.RefundIndex = dec.RefundIndex
+	s.RefundRecipient = dec.RefundRecipient
+	s.RefundTxHashes = dec.RefundTxHashes
+	s.Builders = dec.Builders
+	return nil
+}
+
+func (s *SendBundleRequest) txs() (types.Transactions, error) {
+	return decodeTxs(s.Transactions, s.RawTransactions)
+}
+
 type sendBundleResponse struct {
 	BundleHash common.Hash `json:"bundleHash"`
 }
@@ -95,10 +147,54 @@ func (f *sendBundleFactory) CreateRequest() (rpc.BatchElem, error) {
 // HandleResponse implements the [w3types.ResponseHandler].
 func (f *sendBundleFactory) HandleResponse(elem rpc.BatchElem) error {
 	if err := elem.Error; err != nil {
-		return err
+		return wrapRPCError(err)
 	}
 	if f.returns != nil {
 		*f.returns = f.result.BundleHash
 	}
 	return nil
 }
+
+func (f *sendBundleFactory) validate() error { return f.param.Validate() }
+
+type cancelBundleRequest struct {
+	ReplacementUuid uuid.UUID `json:"replacementUuid"`
+}
+
+// CancelBundle cancels the bundles that were sent with the given
+// [SendBundleRequest.ReplacementUuid].
+func CancelBundle(replacementUuid uuid.UUID) w3types.RPCCallerFactory[bool] {
+	return &cancelBundleFactory{replacementUuid: replacementUuid}
+}
+
+type cancelBundleFactory struct {
+	// args
+	replacementUuid uuid.UUID
+
+	// returns
+	returns *bool
+}
+
+func (f *cancelBundleFactory) Returns(success *bool) w3types.RPCCaller {
+	f.returns = success
+	return f
+}
+
+// CreateRequest implements the [w3types.RequestCreator].
+func (f *cancelBundleFactory) CreateRequest() (rpc.BatchElem, error) {
+	return rpc.BatchElem{
+		Method: "eth_cancelBundle",
+		Args: []any{&cancelBundleRequest{
+			ReplacementUuid: f.replacementUuid,
+		}},
+		Result: &f.returns,
+	}, nil
+}
+
+// HandleResponse implements the [w3types.ResponseHandler].
+func (f *cancelBundleFactory) HandleResponse(elem rpc.BatchElem) error {
+	if err := elem.Error; err != nil {
+		return wrapRPCError(err)
+	}
+	return nil
+}
@@ -22,5 +22,48 @@ func TestSendBundle(t *testing.T) {
 			}),
 			WantRet: w3.H("0x2228f5d8954ce31dc1601a8ba264dbd401bf1428388ce88238932815c5d6f23f"),
 		},
+		{
+			Golden: "send_bundle_refund",
+			Call: flashbots.SendBundle(&flashbots.SendBundleRequest{
+				RawTransactions:   [][]byte{w3.B("0x00"), w3.B("0x01")},
+				BlockNumber:       big.NewInt(9_999_999),
+				MinTimestamp:      1615920932,
+				MaxTimestamp:      1615920944,
+				RevertingTxHashes: []common.Hash{w3.H("0x669b4704a7d993a946cdd6e2f95233f308ce0c4649d2e04944e8299efcaa098a")},
+				DroppingTxHashes:  []common.Hash{w3.H("0xa839ee83465657cac01adc1d50d96c1b586ed498120a84a64749c0034b4f19fa")},
+				ReplacementUuid:   uuid.MustParse("2c9cf5d0-f13c-4b7a-b51d-f462fdb27b51"),
+				ReplacementNonce:  ptr[uint64](1),
+				RefundPercent:     90,
+				RefundIndex:       ptr(1),
+				RefundRecipient:   w3.APtr("0x000000000000000000000000000000000000c0Fe"),
+				RefundTxHashes:    []common.Hash{w3.H("0x669b4704a7d993a946cdd6e2f95233f308ce0c4649d2e04944e8299efcaa098a")},
+				Builders:          []string{"flashbots", "beaverbuild.org", "Titan"},
+			}),
+			WantRet: w3.H("0x2228f5d8954ce31dc1601a8ba264dbd401bf1428388ce88238932815c5d6f23f"),
+		},
+		{
+			Golden: "send_bundle_refund_zero",
+			Call: flashbots.SendBundle(&flashbots.SendBundleRequest{
+				RawTransactions:  [][]byte{w3.B("0x00"), w3.B("0x01")},
+				BlockNumber:      big.NewInt(9_999_999),
+				ReplacementUuid:  uuid.MustParse("2c9cf5d0-f13c-4b7a-b51d-f462fdb27b51"),
+				ReplacementNonce: ptr[uint64](0),
+				RefundPercent:    90,
+				RefundIndex:      ptr(0),
+			}),
+			WantRet: w3.H("0x2228f5d8954ce31dc1601a8ba264dbd401bf1428388ce88238932815c5d6f23f"),
+		},
+	})
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestCancelBundle(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[bool]{
+		{
+			Golden:  "cancel_bundle",
+			Call:    flashbots.CancelBundle(uuid.MustParse("2c9cf5d0-f13c-4b7a-b51d-f462fdb27b51")),
+			WantRet: true,
+		},
 	})
 }
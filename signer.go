@@ -0,0 +1,103 @@
+package flashbots
+
+import (
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer signs the payload of requests to the Flashbots relay.
+type Signer interface {
+	// Address returns the address of the signer.
+	Address() common.Address
+
+	// SignHash returns the EIP-191 personal signature of the hex encoded
+	// payload hash in the [R || S || V] format.
+	SignHash(hash common.Hash) ([]byte, error)
+}
+
+// NewPrivateKeySigner returns a [Signer] that signs with the given private key.
+func NewPrivateKeySigner(prv *ecdsa.PrivateKey) Signer {
+	return &privateKeySigner{prv: prv, addr: crypto.PubkeyToAddress(prv.PublicKey)}
+}
+
+type privateKeySigner struct {
+	prv  *ecdsa.PrivateKey
+	addr common.Address
+}
+
+func (s *privateKeySigner) Address() common.Address { return s.addr }
+
+func (s *privateKeySigner) SignHash(hash common.Hash) ([]byte, error) {
+	return crypto.Sign(textHash(hash), s.prv)
+}
+
+// NewKeyfileSigner returns a [Signer] that signs with the key of the given
+// encrypted keystore file.
+func NewKeyfileSigner(keyJSON []byte, passphrase string) (Signer, error) {
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return NewPrivateKeySigner(key.PrivateKey), nil
+}
+
+// NewWalletSigner returns a [Signer] that signs with the given account of the
+// wallet (e.g. an unlocked [keystore.KeyStore] account, a hardware wallet, or
+// an external signer).
+func NewWalletSigner(wallet accounts.Wallet, account accounts.Account) Signer {
+	return &walletSigner{wallet: wallet, account: account}
+}
+
+type walletSigner struct {
+	wallet  accounts.Wallet
+	account accounts.Account
+}
+
+func (s *walletSigner) Address() common.Address { return s.account.Address }
+
+func (s *walletSigner) SignHash(hash common.Hash) ([]byte, error) {
+	return s.wallet.SignText(s.account, []byte(hash.Hex()))
+}
+
+// DialClefSigner returns a [Signer] that signs with the given address using
+// the external signer (e.g. clef) at the given IPC or HTTP endpoint.
+func DialClefSigner(endpoint string, addr common.Address) (Signer, error) {
+	wallet, err := external.NewExternalSigner(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return NewWalletSigner(wallet, accounts.Account{Address: addr}), nil
+}
+
+// NewRemoteSigner returns a [Signer] for the given address that delegates
+// signing to the function signDigest, e.g. to call a HSM or KMS. signDigest
+// must return the signature of the given 32 byte digest in the
+// [R || S || V] format.
+func NewRemoteSigner(addr common.Address, signDigest func(digest []byte) ([]byte, error)) Signer {
+	return &remoteSigner{addr: addr, signDigest: signDigest}
+}
+
+type remoteSigner struct {
+	addr       common.Address
+	signDigest func(digest []byte) ([]byte, error)
+}
+
+func (s *remoteSigner) Address() common.Address { return s.addr }
+
+func (s *remoteSigner) SignHash(hash common.Hash) ([]byte, error) {
+	if s.signDigest == nil {
+		return nil, errors.New("flashbots: sign function is nil")
+	}
+	return s.signDigest(textHash(hash))
+}
+
+// textHash returns the EIP-191 digest of the hex encoded hash.
+func textHash(hash common.Hash) []byte {
+	return accounts.TextHash([]byte(hash.Hex()))
+}
@@ -0,0 +1,126 @@
+package flashbots
+
+import (
+	"crypto/ecdsa"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/google/uuid"
+)
+
+func TestSigners(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+	if err != nil {
+		t.Fatalf("Failed to read key: %v", err)
+	}
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_sendBundle","params":[{"txs":["0x00","0x01"],"blockNumber":"0x98967f"}]}`)
+	wantSig := "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf:0x2765bcbc32f0c6fc822e1d34e188f8337ec52524a7fd4346ba3ca785f3c641a51aaabe9b9392657ab0fd635fb0b527b2dacca7fea1b6b1c3eae553ded693073e01"
+
+	tests := []struct {
+		Name      string
+		NewSigner func(t *testing.T) Signer
+	}{
+		{
+			Name: "private_key",
+			NewSigner: func(t *testing.T) Signer {
+				return NewPrivateKeySigner(privKey)
+			},
+		},
+		{
+			Name: "keyfile",
+			NewSigner: func(t *testing.T) Signer {
+				keyJSON, err := keystore.EncryptKey(
+					&keystore.Key{Id: uuid.New(), Address: addr, PrivateKey: privKey},
+					"pass", keystore.LightScryptN, keystore.LightScryptP,
+				)
+				if err != nil {
+					t.Fatalf("Failed to encrypt key: %v", err)
+				}
+				signer, err := NewKeyfileSigner(keyJSON, "pass")
+				if err != nil {
+					t.Fatalf("Failed to create signer: %v", err)
+				}
+				return signer
+			},
+		},
+		{
+			Name: "wallet",
+			NewSigner: func(t *testing.T) Signer {
+				ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+				account, err := ks.ImportECDSA(privKey, "pass")
+				if err != nil {
+					t.Fatalf("Failed to import key: %v", err)
+				}
+				if err := ks.Unlock(account, "pass"); err != nil {
+					t.Fatalf("Failed to unlock account: %v", err)
+				}
+				return NewWalletSigner(ks.Wallets()[0], account)
+			},
+		},
+		{
+			Name: "clef",
+			NewSigner: func(t *testing.T) Signer {
+				rpcSrv := rpc.NewServer()
+				if err := rpcSrv.RegisterName("account", &clefAPI{privKey}); err != nil {
+					t.Fatalf("Failed to register API: %v", err)
+				}
+				srv := httptest.NewServer(rpcSrv)
+				t.Cleanup(srv.Close)
+
+				signer, err := DialClefSigner(srv.URL, addr)
+				if err != nil {
+					t.Fatalf("Failed to dial signer: %v", err)
+				}
+				return signer
+			},
+		},
+		{
+			Name: "remote",
+			NewSigner: func(t *testing.T) Signer {
+				return NewRemoteSigner(addr, func(digest []byte) ([]byte, error) {
+					return crypto.Sign(digest, privKey)
+				})
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			authRT := &authRoundTripper{signer: test.NewSigner(t)}
+			gotSig, err := authRT.sign(body)
+			if err != nil {
+				t.Fatalf("Failed to sign body: %v", err)
+			}
+			if wantSig != gotSig {
+				t.Fatalf("want %s\ngot  %s", wantSig, gotSig)
+			}
+		})
+	}
+}
+
+// clefAPI implements the subset of the clef external API that is used by the
+// [DialClefSigner].
+type clefAPI struct {
+	privKey *ecdsa.PrivateKey
+}
+
+func (api *clefAPI) Version() string { return "6.0.0" }
+
+func (api *clefAPI) SignData(contentType string, addr common.MixedcaseAddress, data hexutil.Bytes) (hexutil.Bytes, error) {
+	sig, err := crypto.Sign(accounts.TextHash(data), api.privKey)
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27 // clef returns V in the Ethereum-legacy format
+	return sig, nil
+}
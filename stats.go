@@ -82,7 +82,7 @@ func (f *bundleStatsFactory) CreateRequest() (rpc.BatchElem, error) {
 
 func (f *bundleStatsFactory) HandleResponse(elem rpc.BatchElem) error {
 	if err := elem.Error; err != nil {
-		return err
+		return wrapRPCError(err)
 	}
 	return nil
 }
@@ -130,7 +130,7 @@ func (f *bundleStatsV2Factory) CreateRequest() (rpc.BatchElem, error) {
 
 func (f *bundleStatsV2Factory) HandleResponse(elem rpc.BatchElem) error {
 	if err := elem.Error; err != nil {
-		return err
+		return wrapRPCError(err)
 	}
 	return nil
 }
@@ -210,7 +210,7 @@ func (f *userStatsFactory) CreateRequest() (rpc.BatchElem, error) {
 
 func (f *userStatsFactory) HandleResponse(elem rpc.BatchElem) error {
 	if err := elem.Error; err != nil {
-		return err
+		return wrapRPCError(err)
 	}
 	return nil
 }
@@ -295,7 +295,7 @@ func (f *userStatsV2Factory) CreateRequest() (rpc.BatchElem, error) {
 
 func (f *userStatsV2Factory) HandleResponse(elem rpc.BatchElem) error {
 	if err := elem.Error; err != nil {
-		return err
+		return wrapRPCError(err)
 	}
 	return nil
 }
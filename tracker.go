@@ -0,0 +1,242 @@
+package flashbots
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/eth"
+)
+
+// ChainSource provides the chain data that is used to track bundles.
+type ChainSource interface {
+	// SubscribeNewHeads sends the header of every new block to ch.
+	SubscribeNewHeads(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+
+	// BlockByNumber returns the block with the given number.
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+
+	// NonceAt returns the nonce of the account at the given block number.
+	NonceAt(ctx context.Context, addr common.Address, number *big.Int) (uint64, error)
+}
+
+// NewChainSource returns a [ChainSource] that is backed by the given client.
+// The client must be connected via WebSocket or IPC to subscribe to new heads.
+func NewChainSource(client *w3.Client) ChainSource {
+	return &clientChainSource{client: client}
+}
+
+type clientChainSource struct {
+	client *w3.Client
+}
+
+func (s *clientChainSource) SubscribeNewHeads(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return s.client.SubscribeCtx(ctx, eth.NewHeads(ch))
+}
+
+func (s *clientChainSource) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	var block *types.Block
+	if err := s.client.CallCtx(ctx, eth.BlockByNumber(number).Returns(&block)); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (s *clientChainSource) NonceAt(ctx context.Context, addr common.Address, number *big.Int) (uint64, error) {
+	var nonce uint64
+	if err := s.client.CallCtx(ctx, eth.Nonce(addr, number).Returns(&nonce)); err != nil {
+		return 0, err
+	}
+	return nonce, nil
+}
+
+// OutcomeStatus is the status of a tracked bundle in its target block.
+type OutcomeStatus int
+
+const (
+	OutcomeIncluded          OutcomeStatus = iota + 1 // All txs that are not allowed to revert or be dropped landed contiguously.
+	OutcomePartiallyIncluded                          // Some, but not all txs of the bundle landed, or not contiguously.
+	OutcomeNonceInvalidated                           // No tx of the bundle landed and the nonce of a tx was consumed.
+	OutcomeMissed                                     // No tx of the bundle landed.
+	OutcomeExpired                                    // No tx of the bundle landed and the target block was outside the bundles timestamp range.
+)
+
+func (s OutcomeStatus) String() string {
+	switch s {
+	case OutcomeIncluded:
+		return "included"
+	case OutcomePartiallyIncluded:
+		return "partially included"
+	case OutcomeNonceInvalidated:
+		return "nonce invalidated"
+	case OutcomeMissed:
+		return "missed"
+	case OutcomeExpired:
+		return "expired"
+	default:
+		return "unknown"
+	}
+}
+
+// Outcome is the outcome of a tracked bundle.
+type Outcome struct {
+	Status      OutcomeStatus
+	BlockNumber *big.Int      // Target block number of the bundle.
+	BlockHash   common.Hash   // Hash of the target block.
+	TxHashes    []common.Hash // Hashes of the bundle txs that landed in the target block.
+	Err         error         // Error that occurred while tracking the bundle. Status is not set if Err is non-nil.
+}
+
+// Tracker tracks the inclusion of bundles.
+type Tracker struct {
+	src ChainSource
+}
+
+// NewTracker returns a new [Tracker] that watches the chain of the given
+// source.
+func NewTracker(src ChainSource) *Tracker {
+	return &Tracker{src: src}
+}
+
+// Track watches new heads until the target block [SendBundleRequest.BlockNumber]
+// of the bundle is mined and sends the outcome of the bundle on the returned
+// channel. The channel is closed after the outcome is sent or when ctx is
+// canceled.
+func (t *Tracker) Track(ctx context.Context, r *SendBundleRequest) (<-chan Outcome, error) {
+	if r.BlockNumber == nil {
+		return nil, errors.New("flashbots: bundle block number is nil")
+	}
+	txs, err := r.txs()
+	if err != nil {
+		return nil, err
+	}
+
+	heads := make(chan *types.Header)
+	sub, err := t.src.SubscribeNewHeads(ctx, heads)
+	if err != nil {
+		return nil, err
+	}
+
+	outcomes := make(chan Outcome, 1)
+	go func() {
+		defer close(outcomes)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case head := <-heads:
+				if head.Number.Cmp(r.BlockNumber) < 0 {
+					continue
+				}
+				outcomes <- bundleOutcome(ctx, t.src, r, txs)
+				return
+			case err := <-sub.Err():
+				if err != nil {
+					outcomes <- Outcome{BlockNumber: r.BlockNumber, Err: err}
+				}
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return outcomes, nil
+}
+
+// bundleOutcome returns the outcome of the bundle with the given txs in its
+// target block.
+func bundleOutcome(ctx context.Context, src ChainSource, r *SendBundleRequest, txs types.Transactions) Outcome {
+	outcome := Outcome{BlockNumber: r.BlockNumber}
+
+	block, err := src.BlockByNumber(ctx, r.BlockNumber)
+	if err != nil {
+		outcome.Err = err
+		return outcome
+	}
+	outcome.BlockHash = block.Hash()
+
+	blockTxIndex := make(map[common.Hash]int, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		blockTxIndex[tx.Hash()] = i
+	}
+	optional := make(map[common.Hash]bool, len(r.RevertingTxHashes)+len(r.DroppingTxHashes))
+	for _, txHash := range r.RevertingTxHashes {
+		optional[txHash] = true
+	}
+	for _, txHash := range r.DroppingTxHashes {
+		optional[txHash] = true
+	}
+
+	var (
+		prevIndex       = -1
+		contiguous      = true
+		missingRequired = false
+	)
+	for _, tx := range txs {
+		txHash := tx.Hash()
+		i, ok := blockTxIndex[txHash]
+		if !ok {
+			missingRequired = missingRequired || !optional[txHash]
+			continue
+		}
+		if prevIndex >= 0 && i != prevIndex+1 {
+			contiguous = false
+		}
+		prevIndex = i
+		outcome.TxHashes = append(outcome.TxHashes, txHash)
+	}
+
+	switch {
+	case len(outcome.TxHashes) > 0 && !missingRequired && contiguous:
+		outcome.Status = OutcomeIncluded
+		return outcome
+	case len(outcome.TxHashes) > 0:
+		outcome.Status = OutcomePartiallyIncluded
+		return outcome
+	}
+
+	consumed, err := nonceConsumed(ctx, src, txs, r.BlockNumber)
+	if err != nil {
+		outcome.Err = err
+		return outcome
+	}
+
+	switch {
+	case consumed:
+		outcome.Status = OutcomeNonceInvalidated
+	case r.MinTimestamp > 0 && block.Time() < r.MinTimestamp,
+		r.MaxTimestamp > 0 && block.Time() > r.MaxTimestamp:
+		outcome.Status = OutcomeExpired
+	default:
+		outcome.Status = OutcomeMissed
+	}
+	return outcome
+}
+
+// nonceConsumed returns true if the nonce of any of the given txs was consumed
+// at the given block number.
+func nonceConsumed(ctx context.Context, src ChainSource, txs types.Transactions, number *big.Int) (bool, error) {
+	nonces := make(map[common.Address]uint64)
+	for _, tx := range txs {
+		from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			return false, err
+		}
+
+		nonce, ok := nonces[from]
+		if !ok {
+			if nonce, err = src.NonceAt(ctx, from, number); err != nil {
+				return false, err
+			}
+			nonces[from] = nonce
+		}
+		if nonce > tx.Nonce() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,146 @@
+package flashbots_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/flashbots"
+	"github.com/lmittmann/flashbots/flashbotstest"
+	"github.com/lmittmann/w3"
+)
+
+func TestTrackerTrack(t *testing.T) {
+	prv, _ := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+	prv2, _ := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000002")
+
+	var (
+		tx0      = newTx(prv, 0, 1)
+		tx1      = newTx(prv, 1, 1)
+		txNonce0 = newTx(prv, 0, 2) // competing tx with the same nonce as tx0
+		txOther  = newTx(prv2, 0, 1)
+	)
+
+	tests := []struct {
+		Name        string
+		Request     *flashbots.SendBundleRequest
+		Blocks      []types.Transactions
+		WantOutcome flashbots.Outcome
+	}{
+		{
+			Name:    "included",
+			Request: &flashbots.SendBundleRequest{Transactions: types.Transactions{tx0, tx1}, BlockNumber: big.NewInt(2)},
+			Blocks:  []types.Transactions{{}, {txOther, tx0, tx1}},
+			WantOutcome: flashbots.Outcome{
+				Status:   flashbots.OutcomeIncluded,
+				TxHashes: []common.Hash{tx0.Hash(), tx1.Hash()},
+			},
+		},
+		{
+			Name: "included_without_reverting_tx",
+			Request: &flashbots.SendBundleRequest{
+				Transactions:      types.Transactions{tx0, tx1},
+				BlockNumber:       big.NewInt(2),
+				RevertingTxHashes: []common.Hash{tx1.Hash()},
+			},
+			Blocks: []types.Transactions{{}, {tx0}},
+			WantOutcome: flashbots.Outcome{
+				Status:   flashbots.OutcomeIncluded,
+				TxHashes: []common.Hash{tx0.Hash()},
+			},
+		},
+		{
+			Name:    "partially_included",
+			Request: &flashbots.SendBundleRequest{Transactions: types.Transactions{tx0, tx1}, BlockNumber: big.NewInt(2)},
+			Blocks:  []types.Transactions{{}, {tx0}},
+			WantOutcome: flashbots.Outcome{
+				Status:   flashbots.OutcomePartiallyIncluded,
+				TxHashes: []common.Hash{tx0.Hash()},
+			},
+		},
+		{
+			Name:    "not_contiguous",
+			Request: &flashbots.SendBundleRequest{Transactions: types.Transactions{tx0, tx1}, BlockNumber: big.NewInt(2)},
+			Blocks:  []types.Transactions{{}, {tx0, txOther, tx1}},
+			WantOutcome: flashbots.Outcome{
+				Status:   flashbots.OutcomePartiallyIncluded,
+				TxHashes: []common.Hash{tx0.Hash(), tx1.Hash()},
+			},
+		},
+		{
+			Name:        "nonce_invalidated",
+			Request:     &flashbots.SendBundleRequest{Transactions: types.Transactions{tx0, tx1}, BlockNumber: big.NewInt(2)},
+			Blocks:      []types.Transactions{{txNonce0}, {}},
+			WantOutcome: flashbots.Outcome{Status: flashbots.OutcomeNonceInvalidated},
+		},
+		{
+			Name:        "missed",
+			Request:     &flashbots.SendBundleRequest{Transactions: types.Transactions{tx0, tx1}, BlockNumber: big.NewInt(2)},
+			Blocks:      []types.Transactions{{}, {txOther}},
+			WantOutcome: flashbots.Outcome{Status: flashbots.OutcomeMissed},
+		},
+		{
+			Name: "expired",
+			Request: &flashbots.SendBundleRequest{
+				Transactions: types.Transactions{tx0, tx1},
+				BlockNumber:  big.NewInt(2),
+				MaxTimestamp: 23,
+			},
+			Blocks:      []types.Transactions{{}, {}},
+			WantOutcome: flashbots.Outcome{Status: flashbots.OutcomeExpired},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			chain := flashbotstest.NewChain()
+			tracker := flashbots.NewTracker(chain)
+			outcomes, err := tracker.Track(ctx, test.Request)
+			if err != nil {
+				t.Fatalf("Failed to track bundle: %v", err)
+			}
+
+			var target *types.Block
+			for _, txs := range test.Blocks {
+				target = chain.AddBlock(txs...)
+			}
+
+			gotOutcome, ok := <-outcomes
+			if !ok {
+				t.Fatal("Outcome channel closed")
+			}
+			test.WantOutcome.BlockNumber = test.Request.BlockNumber
+			test.WantOutcome.BlockHash = target.Hash()
+			if diff := cmp.Diff(test.WantOutcome, gotOutcome,
+				cmp.AllowUnexported(big.Int{}),
+			); diff != "" {
+				t.Fatalf("(-want, +got)\n%s", diff)
+			}
+			if _, ok := <-outcomes; ok {
+				t.Fatal("Outcome channel not closed")
+			}
+		})
+	}
+}
+
+func newTx(prv *ecdsa.PrivateKey, nonce uint64, tip int64) *types.Transaction {
+	to := common.Address{0xc0, 0xfe}
+	return types.MustSignNewTx(prv, types.LatestSigner(params.MainnetChainConfig), &types.DynamicFeeTx{
+		ChainID:   params.MainnetChainConfig.ChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(tip),
+		GasFeeCap: w3.I("10 gwei"),
+		Gas:       21_000,
+		To:        &to,
+	})
+}
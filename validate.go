@@ -0,0 +1,206 @@
+package flashbots
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// Validation errors. The errors returned by the Validate methods of requests
+// are of type [*ValidationError] and wrap one of these errors.
+var (
+	ErrEmptyBundle           = errors.New("flashbots: empty bundle")
+	ErrMissingTx             = errors.New("flashbots: missing tx")
+	ErrAmbiguousTxs          = errors.New("flashbots: both txs and raw txs set")
+	ErrMissingBlockNumber    = errors.New("flashbots: missing block number")
+	ErrInvalidTimestampRange = errors.New("flashbots: min timestamp after max timestamp")
+	ErrInvalidTx             = errors.New("flashbots: invalid tx")
+	ErrUnsignedTx            = errors.New("flashbots: unsigned tx")
+	ErrDuplicateTx           = errors.New("flashbots: duplicate tx")
+	ErrMixedChainIDs         = errors.New("flashbots: mixed chain IDs")
+	ErrNonceGap              = errors.New("flashbots: non-sequential nonce")
+	ErrUnknownTxHash         = errors.New("flashbots: tx hash not in bundle")
+)
+
+// ValidationError is returned if a request is invalid.
+type ValidationError struct {
+	Field string // Name of the invalid field, e.g. "Transactions[1]".
+	Err   error  // Cause of the error.
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s (%s)", e.Err, e.Field)
+}
+
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+// Validate returns a [*ValidationError] if the bundle is empty, has both
+// Transactions and RawTransactions set, misses the BlockNumber, has a
+// MinTimestamp after the MaxTimestamp, contains unsigned or duplicate txs, txs
+// with mixed chain IDs, or non-sequential nonces per sender, or if the
+// RevertingTxHashes, DroppingTxHashes, or RefundTxHashes contain a tx hash that
+// is not in the bundle.
+func (s *SendBundleRequest) Validate() error {
+	field, txs, err := validateBundleTxs(s.Transactions, s.RawTransactions)
+	if err != nil {
+		return &ValidationError{Field: field, Err: err}
+	}
+	if s.BlockNumber == nil {
+		return &ValidationError{Field: "BlockNumber", Err: ErrMissingBlockNumber}
+	}
+	if s.MaxTimestamp > 0 && s.MinTimestamp > s.MaxTimestamp {
+		return &ValidationError{Field: "MinTimestamp", Err: ErrInvalidTimestampRange}
+	}
+
+	txHashes := make(map[common.Hash]bool, len(txs))
+	for _, tx := range txs {
+		txHashes[tx.Hash()] = true
+	}
+	for _, hashes := range []struct {
+		Field  string
+		Hashes []common.Hash
+	}{
+		{"RevertingTxHashes", s.RevertingTxHashes},
+		{"DroppingTxHashes", s.DroppingTxHashes},
+		{"RefundTxHashes", s.RefundTxHashes},
+	} {
+		for i, txHash := range hashes.Hashes {
+			if !txHashes[txHash] {
+				return &ValidationError{Field: fmt.Sprintf("%s[%d]", hashes.Field, i), Err: ErrUnknownTxHash}
+			}
+		}
+	}
+	return nil
+}
+
+// Validate returns a [*ValidationError] if the bundle is empty, has both
+// Transactions and RawTransactions set, misses the BlockNumber, or contains
+// unsigned or duplicate txs, txs with mixed chain IDs, or non-sequential nonces
+// per sender.
+func (c *CallBundleRequest) Validate() error {
+	if field, _, err := validateBundleTxs(c.Transactions, c.RawTransactions); err != nil {
+		return &ValidationError{Field: field, Err: err}
+	}
+	if c.BlockNumber == nil {
+		return &ValidationError{Field: "BlockNumber", Err: ErrMissingBlockNumber}
+	}
+	return nil
+}
+
+// Validate returns a [*ValidationError] if the request has none or both of Tx
+// and RawTx set, or if the tx is unsigned.
+func (c *SendPrivateTxRequest) Validate() error {
+	tx := c.Tx
+	switch {
+	case c.Tx != nil && c.RawTx != nil:
+		return &ValidationError{Field: "RawTx", Err: ErrAmbiguousTxs}
+	case c.Tx == nil && c.RawTx == nil:
+		return &ValidationError{Field: "Tx", Err: ErrMissingTx}
+	case c.Tx == nil:
+		tx = new(types.Transaction)
+		if err := tx.UnmarshalBinary(c.RawTx); err != nil {
+			return &ValidationError{Field: "RawTx", Err: fmt.Errorf("%w: %v", ErrInvalidTx, err)}
+		}
+	}
+
+	if _, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx); err != nil {
+		field := "Tx"
+		if c.Tx == nil {
+			field = "RawTx"
+		}
+		return &ValidationError{Field: field, Err: ErrUnsignedTx}
+	}
+	return nil
+}
+
+// validateBundleTxs validates the txs of a bundle and returns them. The name
+// of the invalid field is returned with the error.
+func validateBundleTxs(txs types.Transactions, rawTxs [][]byte) (string, types.Transactions, error) {
+	field := "Transactions"
+	switch {
+	case len(txs) > 0 && len(rawTxs) > 0:
+		return "RawTransactions", nil, ErrAmbiguousTxs
+	case len(txs) <= 0 && len(rawTxs) <= 0:
+		return field, nil, ErrEmptyBundle
+	case len(txs) <= 0:
+		field = "RawTransactions"
+		txs = make(types.Transactions, len(rawTxs))
+		for i, rawTx := range rawTxs {
+			tx := new(types.Transaction)
+			if err := tx.UnmarshalBinary(rawTx); err != nil {
+				return fmt.Sprintf("%s[%d]", field, i), nil, fmt.Errorf("%w: %v", ErrInvalidTx, err)
+			}
+			txs[i] = tx
+		}
+	}
+
+	var (
+		chainID  *big.Int
+		txHashes = make(map[common.Hash]bool, len(txs))
+		nonces   = make(map[common.Address]uint64) // next nonce per sender
+	)
+	for i, tx := range txs {
+		txField := fmt.Sprintf("%s[%d]", field, i)
+
+		txHash := tx.Hash()
+		if txHashes[txHash] {
+			return txField, nil, ErrDuplicateTx
+		}
+		txHashes[txHash] = true
+
+		if tx.Protected() {
+			if chainID == nil {
+				chainID = tx.ChainId()
+			} else if chainID.Cmp(tx.ChainId()) != 0 {
+				return txField, nil, ErrMixedChainIDs
+			}
+		}
+
+		from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			return txField, nil, ErrUnsignedTx
+		}
+		if nonce, ok := nonces[from]; ok && tx.Nonce() != nonce {
+			return txField, nil, ErrNonceGap
+		}
+		nonces[from] = tx.Nonce() + 1
+	}
+	return "", txs, nil
+}
+
+// Validated returns a factory that validates the request of the given factory
+// before it is sent. The request is not sent and CreateRequest returns the
+// validation error if the request is invalid.
+//
+// Requests of the factories [SendBundle], [CallBundle], [SendPrivateTx], and
+// [SendPrivateRawTx] are validated. Requests of other factories are sent as is.
+func Validated[T any](f w3types.RPCCallerFactory[T]) w3types.RPCCallerFactory[T] {
+	return &validatedFactory[T]{factory: f}
+}
+
+type validatedFactory[T any] struct {
+	factory w3types.RPCCallerFactory[T]
+}
+
+func (f *validatedFactory[T]) Returns(ret *T) w3types.RPCCaller {
+	return &validatedCaller{f.factory.Returns(ret)}
+}
+
+type validatedCaller struct {
+	w3types.RPCCaller
+}
+
+// CreateRequest implements the [w3types.RequestCreator].
+func (c *validatedCaller) CreateRequest() (rpc.BatchElem, error) {
+	if v, ok := c.RPCCaller.(interface{ validate() error }); ok {
+		if err := v.validate(); err != nil {
+			return rpc.BatchElem{}, err
+		}
+	}
+	return c.RPCCaller.CreateRequest()
+}
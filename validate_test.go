@@ -0,0 +1,276 @@
+package flashbots_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lmittmann/flashbots"
+	"github.com/lmittmann/flashbots/flashbotstest"
+	"github.com/lmittmann/w3"
+)
+
+func TestSendBundleRequestValidate(t *testing.T) {
+	prv, _ := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+	prv2, _ := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000002")
+
+	var (
+		tx0        = newTx(prv, 0, 1)
+		tx1        = newTx(prv, 1, 1)
+		tx2        = newTx(prv, 2, 1)
+		txOther    = newTx(prv2, 5, 1)
+		rawTx0, _  = tx0.MarshalBinary()
+		unsignedTx = types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Gas: 21_000})
+		sepoliaTx  = types.MustSignNewTx(prv2, types.LatestSignerForChainID(big.NewInt(11155111)), &types.DynamicFeeTx{
+			ChainID: big.NewInt(11155111),
+			Gas:     21_000,
+		})
+	)
+
+	tests := []struct {
+		Name      string
+		Request   *flashbots.SendBundleRequest
+		WantErr   error
+		WantField string
+	}{
+		{
+			Name: "valid",
+			Request: &flashbots.SendBundleRequest{
+				Transactions:      types.Transactions{tx0, txOther, tx1},
+				BlockNumber:       big.NewInt(1),
+				MinTimestamp:      1,
+				MaxTimestamp:      2,
+				RevertingTxHashes: []common.Hash{txOther.Hash()},
+			},
+		},
+		{
+			Name:    "valid_raw",
+			Request: &flashbots.SendBundleRequest{RawTransactions: [][]byte{rawTx0}, BlockNumber: big.NewInt(1)},
+		},
+		{
+			Name:      "empty",
+			Request:   &flashbots.SendBundleRequest{BlockNumber: big.NewInt(1)},
+			WantErr:   flashbots.ErrEmptyBundle,
+			WantField: "Transactions",
+		},
+		{
+			Name: "ambiguous",
+			Request: &flashbots.SendBundleRequest{
+				Transactions:    types.Transactions{tx0},
+				RawTransactions: [][]byte{rawTx0},
+				BlockNumber:     big.NewInt(1),
+			},
+			WantErr:   flashbots.ErrAmbiguousTxs,
+			WantField: "RawTransactions",
+		},
+		{
+			Name:      "missing_block_number",
+			Request:   &flashbots.SendBundleRequest{Transactions: types.Transactions{tx0}},
+			WantErr:   flashbots.ErrMissingBlockNumber,
+			WantField: "BlockNumber",
+		},
+		{
+			Name: "invalid_timestamp_range",
+			Request: &flashbots.SendBundleRequest{
+				Transactions: types.Transactions{tx0},
+				BlockNumber:  big.NewInt(1),
+				MinTimestamp: 2,
+				MaxTimestamp: 1,
+			},
+			WantErr:   flashbots.ErrInvalidTimestampRange,
+			WantField: "MinTimestamp",
+		},
+		{
+			Name:      "invalid_raw_tx",
+			Request:   &flashbots.SendBundleRequest{RawTransactions: [][]byte{rawTx0, w3.B("0x00")}, BlockNumber: big.NewInt(1)},
+			WantErr:   flashbots.ErrInvalidTx,
+			WantField: "RawTransactions[1]",
+		},
+		{
+			Name:      "unsigned_tx",
+			Request:   &flashbots.SendBundleRequest{Transactions: types.Transactions{tx0, unsignedTx}, BlockNumber: big.NewInt(1)},
+			WantErr:   flashbots.ErrUnsignedTx,
+			WantField: "Transactions[1]",
+		},
+		{
+			Name:      "duplicate_tx",
+			Request:   &flashbots.SendBundleRequest{Transactions: types.Transactions{tx0, tx0}, BlockNumber: big.NewInt(1)},
+			WantErr:   flashbots.ErrDuplicateTx,
+			WantField: "Transactions[1]",
+		},
+		{
+			Name:      "mixed_chain_ids",
+			Request:   &flashbots.SendBundleRequest{Transactions: types.Transactions{tx0, sepoliaTx}, BlockNumber: big.NewInt(1)},
+			WantErr:   flashbots.ErrMixedChainIDs,
+			WantField: "Transactions[1]",
+		},
+		{
+			Name:      "nonce_gap",
+			Request:   &flashbots.SendBundleRequest{Transactions: types.Transactions{tx0, txOther, tx2}, BlockNumber: big.NewInt(1)},
+			WantErr:   flashbots.ErrNonceGap,
+			WantField: "Transactions[2]",
+		},
+		{
+			Name: "unknown_reverting_tx_hash",
+			Request: &flashbots.SendBundleRequest{
+				Transactions:      types.Transactions{tx0},
+				BlockNumber:       big.NewInt(1),
+				RevertingTxHashes: []common.Hash{tx0.Hash(), tx1.Hash()},
+			},
+			WantErr:   flashbots.ErrUnknownTxHash,
+			WantField: "RevertingTxHashes[1]",
+		},
+		{
+			Name: "unknown_refund_tx_hash",
+			Request: &flashbots.SendBundleRequest{
+				Transactions:   types.Transactions{tx0},
+				BlockNumber:    big.NewInt(1),
+				RefundTxHashes: []common.Hash{tx1.Hash()},
+			},
+			WantErr:   flashbots.ErrUnknownTxHash,
+			WantField: "RefundTxHashes[0]",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assertValidationErr(t, test.Request.Validate(), test.WantErr, test.WantField)
+		})
+	}
+}
+
+func TestCallBundleRequestValidate(t *testing.T) {
+	prv, _ := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+	tx0 := newTx(prv, 0, 1)
+
+	tests := []struct {
+		Name      string
+		Request   *flashbots.CallBundleRequest
+		WantErr   error
+		WantField string
+	}{
+		{
+			Name:    "valid",
+			Request: &flashbots.CallBundleRequest{Transactions: types.Transactions{tx0}, BlockNumber: big.NewInt(1)},
+		},
+		{
+			Name:      "empty",
+			Request:   &flashbots.CallBundleRequest{BlockNumber: big.NewInt(1)},
+			WantErr:   flashbots.ErrEmptyBundle,
+			WantField: "Transactions",
+		},
+		{
+			Name:      "missing_block_number",
+			Request:   &flashbots.CallBundleRequest{Transactions: types.Transactions{tx0}},
+			WantErr:   flashbots.ErrMissingBlockNumber,
+			WantField: "BlockNumber",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assertValidationErr(t, test.Request.Validate(), test.WantErr, test.WantField)
+		})
+	}
+}
+
+func TestSendPrivateTxRequestValidate(t *testing.T) {
+	prv, _ := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+	tx0 := newTx(prv, 0, 1)
+	rawTx0, _ := tx0.MarshalBinary()
+	rawUnsignedTx, _ := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Gas: 21_000}).MarshalBinary()
+
+	tests := []struct {
+		Name      string
+		Request   *flashbots.SendPrivateTxRequest
+		WantErr   error
+		WantField string
+	}{
+		{
+			Name:    "valid",
+			Request: &flashbots.SendPrivateTxRequest{Tx: tx0},
+		},
+		{
+			Name:    "valid_raw",
+			Request: &flashbots.SendPrivateTxRequest{RawTx: rawTx0},
+		},
+		{
+			Name:      "missing_tx",
+			Request:   &flashbots.SendPrivateTxRequest{},
+			WantErr:   flashbots.ErrMissingTx,
+			WantField: "Tx",
+		},
+		{
+			Name:      "ambiguous",
+			Request:   &flashbots.SendPrivateTxRequest{Tx: tx0, RawTx: rawTx0},
+			WantErr:   flashbots.ErrAmbiguousTxs,
+			WantField: "RawTx",
+		},
+		{
+			Name:      "unsigned_tx",
+			Request:   &flashbots.SendPrivateTxRequest{RawTx: rawUnsignedTx},
+			WantErr:   flashbots.ErrUnsignedTx,
+			WantField: "RawTx",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assertValidationErr(t, test.Request.Validate(), test.WantErr, test.WantField)
+		})
+	}
+}
+
+func TestValidated(t *testing.T) {
+	prv, _ := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+
+	srv := flashbotstest.NewServer()
+	defer srv.Close()
+
+	client := flashbots.MustDial(srv.URL(), prv)
+	defer client.Close()
+
+	var bundleHash common.Hash
+	err := client.Call(flashbots.Validated(flashbots.SendBundle(&flashbots.SendBundleRequest{
+		RawTransactions: [][]byte{w3.B("0x00")},
+		BlockNumber:     big.NewInt(1),
+	})).Returns(&bundleHash))
+	if !errors.Is(err, flashbots.ErrInvalidTx) {
+		t.Fatalf("Err: want %v, got %v", flashbots.ErrInvalidTx, err)
+	}
+	if len(srv.Bundles()) != 0 {
+		t.Fatal("Invalid bundle was sent")
+	}
+
+	if err := client.Call(flashbots.Validated(flashbots.SendBundle(&flashbots.SendBundleRequest{
+		Transactions: types.Transactions{newTx(prv, 0, 1)},
+		BlockNumber:  big.NewInt(1),
+	})).Returns(&bundleHash)); err != nil {
+		t.Fatalf("Failed to send bundle: %v", err)
+	}
+	if len(srv.Bundles()) != 1 {
+		t.Fatal("Valid bundle was not sent")
+	}
+}
+
+func assertValidationErr(t *testing.T, err, wantErr error, wantField string) {
+	t.Helper()
+
+	if wantErr == nil {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return
+	}
+
+	var validationErr *flashbots.ValidationError
+	if !errors.Is(err, wantErr) || !errors.As(err, &validationErr) {
+		t.Fatalf("Err: want %v, got %v", wantErr, err)
+	}
+	if validationErr.Field != wantField {
+		t.Fatalf("Field: want %q, got %q", wantField, validationErr.Field)
+	}
+}